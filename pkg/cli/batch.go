@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dc-tec/helm-schema-gen/pkg/logging"
+	"github.com/dc-tec/helm-schema-gen/pkg/report"
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+// globMetaChars are the characters that mark inputFile as a glob pattern
+// rather than a literal path.
+const globMetaChars = "*?["
+
+// isBatchInput reports whether inputFile should be treated as a directory
+// of charts or a glob pattern (batch mode) rather than a single
+// values.yaml file.
+func isBatchInput(inputFile string) (bool, error) {
+	if strings.ContainsAny(inputFile, globMetaChars) {
+		return true, nil
+	}
+	info, err := os.Stat(inputFile)
+	if err != nil {
+		// A nonexistent path is treated as a single-file input; the usual
+		// "input file not found" error is reported later from wherever it's
+		// resolved relative to.
+		return false, nil
+	}
+	return info.IsDir(), nil
+}
+
+// expandChartInputs resolves inputFile to the values.yaml path of every
+// chart it matches:
+//   - a directory is walked recursively for every file named "values.yaml"
+//   - a pattern containing "**" is split on the first "**/" segment and the
+//     base directory is walked recursively for files matching the remaining
+//     pattern (e.g. "charts/**/values.yaml" matches "values.yaml" under any
+//     depth of charts/); this covers the common recursive-glob case without
+//     pulling in a full glob-matching dependency
+//   - anything else is passed to filepath.Glob
+//
+// Results are returned sorted for deterministic batch ordering.
+func expandChartInputs(inputFile string) ([]string, error) {
+	var matches []string
+
+	switch info, err := os.Stat(inputFile); {
+	case err == nil && info.IsDir():
+		walkErr := filepath.WalkDir(inputFile, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && d.Name() == "values.yaml" {
+				matches = append(matches, path)
+			}
+			return nil
+		})
+		if walkErr != nil {
+			return nil, fmt.Errorf("failed to walk %s: %w", inputFile, walkErr)
+		}
+
+	case strings.Contains(inputFile, "**"):
+		base, suffix, found := strings.Cut(inputFile, "**")
+		if !found {
+			return nil, fmt.Errorf("invalid glob pattern: %s", inputFile)
+		}
+		base = strings.TrimSuffix(base, string(filepath.Separator))
+		suffix = strings.TrimPrefix(suffix, string(filepath.Separator))
+		if base == "" {
+			base = "."
+		}
+		walkErr := filepath.WalkDir(base, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			ok, matchErr := filepath.Match(suffix, d.Name())
+			if matchErr != nil {
+				return matchErr
+			}
+			if ok {
+				matches = append(matches, path)
+			}
+			return nil
+		})
+		if walkErr != nil {
+			return nil, fmt.Errorf("failed to walk %s: %w", base, walkErr)
+		}
+
+	default:
+		globMatches, err := filepath.Glob(inputFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %s: %w", inputFile, err)
+		}
+		matches = globMatches
+	}
+
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// runBatchGenerateCommand expands opts.InputFile to every matching chart's
+// values.yaml, processes them concurrently (bounded by opts.Jobs) through
+// processChart, and writes the aggregated best-practices report in
+// opts.ReportFormat to stdout.
+func runBatchGenerateCommand(ctx context.Context, opts *Options) error {
+	logger := logging.WithComponent(ctx, "cli")
+
+	format, err := report.ParseFormat(opts.ReportFormat)
+	if err != nil {
+		return fmt.Errorf("invalid --report-format: %w", err)
+	}
+
+	chartPaths, err := expandChartInputs(opts.InputFile)
+	if err != nil {
+		return fmt.Errorf("failed to expand chart inputs: %w", err)
+	}
+	if len(chartPaths) == 0 {
+		return fmt.Errorf("no charts matched %s", opts.InputFile)
+	}
+
+	jobs := opts.Jobs
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	logger.InfoContext(ctx, "starting batch generation", "charts", len(chartPaths), "jobs", jobs)
+
+	results := make([]report.ChartResult, len(chartPaths))
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+
+	for i, chartPath := range chartPaths {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, chartPath string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = processChartForBatch(ctx, chartPath, opts)
+		}(i, chartPath)
+	}
+	wg.Wait()
+
+	return report.Write(os.Stdout, format, results)
+}
+
+// processChartForBatch derives per-chart options (input/output paths
+// relative to chartPath's own directory) and runs processChart, attaching
+// resolved line/column positions to any issues found so report.Write's
+// SARIF output can point at the exact spot in the chart's values.yaml.
+func processChartForBatch(ctx context.Context, chartPath string, opts *Options) report.ChartResult {
+	chartDir := filepath.Dir(chartPath)
+	outputPath := opts.OutputFile
+	if !filepath.IsAbs(outputPath) {
+		outputPath = filepath.Join(chartDir, outputPath)
+	}
+
+	schema, issues, err := processChart(ctx, chartPath, outputPath, opts)
+	if err != nil {
+		return report.ChartResult{Path: chartPath, Err: err}
+	}
+
+	if len(issues) > 0 {
+		if yamlData, readErr := os.ReadFile(chartPath); readErr == nil { // #nosec G304 -- chartPath comes from expandChartInputs' own filesystem walk
+			if index, posErr := jsonschema.BuildPositionIndex(yamlData); posErr == nil {
+				issues = jsonschema.AttachPositions(issues, index)
+			}
+		}
+	}
+
+	if err := writeChartSchema(outputPath, schema); err != nil {
+		return report.ChartResult{Path: chartPath, Err: err}
+	}
+
+	return report.ChartResult{Path: chartPath, Issues: issues}
+}
+
+// writeChartSchema creates outputPath's parent directory if needed and
+// writes schema to it, mirroring generateAndWriteSchema's single-file
+// output handling.
+func writeChartSchema(outputPath string, schema *jsonschema.Schema) error {
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	f, err := os.Create(outputPath) // #nosec G304 -- outputPath is derived from a chart path expandChartInputs discovered on disk
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(schema.String()); err != nil {
+		return fmt.Errorf("failed to write schema to file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsBatchInput(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "values.yaml"), []byte("replicaCount: 1\n"), 0600); err != nil {
+		t.Fatalf("failed to write test values file: %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"single file", filepath.Join(dir, "values.yaml"), false},
+		{"directory", dir, true},
+		{"glob pattern", filepath.Join(dir, "*.yaml"), true},
+		{"recursive glob pattern", filepath.Join(dir, "**", "values.yaml"), true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := isBatchInput(c.input)
+			if err != nil {
+				t.Fatalf("isBatchInput(%q) returned an error: %v", c.input, err)
+			}
+			if got != c.want {
+				t.Errorf("isBatchInput(%q) = %v, want %v", c.input, got, c.want)
+			}
+		})
+	}
+}
+
+func TestExpandChartInputs(t *testing.T) {
+	root := t.TempDir()
+	for _, rel := range []string{
+		"charts/api/values.yaml",
+		"charts/worker/values.yaml",
+		"charts/api/values.schema.json",
+	} {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("failed to create test dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("{}"), 0600); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+	}
+
+	t.Run("directory", func(t *testing.T) {
+		matches, err := expandChartInputs(filepath.Join(root, "charts"))
+		if err != nil {
+			t.Fatalf("expandChartInputs returned an error: %v", err)
+		}
+		if len(matches) != 2 {
+			t.Fatalf("expected 2 values.yaml matches, got %d: %v", len(matches), matches)
+		}
+	})
+
+	t.Run("recursive glob", func(t *testing.T) {
+		matches, err := expandChartInputs(filepath.Join(root, "charts", "**", "values.yaml"))
+		if err != nil {
+			t.Fatalf("expandChartInputs returned an error: %v", err)
+		}
+		if len(matches) != 2 {
+			t.Fatalf("expected 2 values.yaml matches, got %d: %v", len(matches), matches)
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		matches, err := expandChartInputs(filepath.Join(root, "nonexistent", "**", "values.yaml"))
+		if err == nil && len(matches) != 0 {
+			t.Fatalf("expected no matches for a nonexistent base directory, got %v", matches)
+		}
+	})
+}
+
+func TestRunBatchGenerateCommand(t *testing.T) {
+	root := t.TempDir()
+	for _, rel := range []string{"api", "worker"} {
+		path := filepath.Join(root, rel, "values.yaml")
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("failed to create test dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("replicaCount: 1\nimage: nginx\n"), 0600); err != nil {
+			t.Fatalf("failed to write test values file: %v", err)
+		}
+	}
+
+	opts := DefaultOptions()
+	opts.InputFile = root
+	opts.OutputFile = "values.schema.json"
+	opts.NoCache = true
+	opts.Jobs = 2
+	opts.ReportFormat = "json"
+
+	if err := runGenerateCommand(context.Background(), opts); err != nil {
+		t.Fatalf("runGenerateCommand returned an error: %v", err)
+	}
+
+	for _, rel := range []string{"api", "worker"} {
+		if _, err := os.Stat(filepath.Join(root, rel, "values.schema.json")); err != nil {
+			t.Errorf("expected a schema to be written for %s: %v", rel, err)
+		}
+	}
+}
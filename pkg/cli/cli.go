@@ -3,51 +3,175 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"runtime"
+
+	examplegen "github.com/dc-tec/helm-schema-gen/pkg/example-generator"
 	"github.com/dc-tec/helm-schema-gen/pkg/logging"
+	"github.com/dc-tec/helm-schema-gen/pkg/plugin"
+	"github.com/dc-tec/helm-schema-gen/pkg/report"
 	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 )
 
 // Configuration options for the CLI
 type Options struct {
 	// Input/output options
-	InputFile  string
-	OutputFile string
+	InputFile  string `yaml:"inputFile"`
+	OutputFile string `yaml:"outputFile"`
 
 	// Schema generation options
-	SchemaVersion string
-	Title         string
-	Description   string
+	SchemaVersion string `yaml:"schemaVersion"`
+	// Draft is a shorthand draft name (4, 6, 7, 2019, or 2020); when set it
+	// overrides SchemaVersion. See jsonschema.ParseDraftShorthand.
+	Draft       string `yaml:"draft"`
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+
+	// RulesFile points to a YAML/JSON file of custom PatternRule entries used
+	// to detect fields that should support multiple schema types.
+	RulesFile string `yaml:"rulesFile"`
+	// ReplaceRules makes RulesFile the complete set of pattern rules rather
+	// than merging it ahead of the built-in Helm-idiom table.
+	ReplaceRules bool `yaml:"replaceRules"`
 
 	// Schema validation options
-	RequireByDefault      bool
-	IncludeExamples       bool
-	ExtractDescriptions   bool
-	ValidateBestPractices bool
+	RequireByDefault      bool `yaml:"requireByDefault"`
+	IncludeExamples       bool `yaml:"includeExamples"`
+	ExtractDescriptions   bool `yaml:"extractDescriptions"`
+	ValidateBestPractices bool `yaml:"validateBestPractices"`
+
+	// InferTuples enables positional (tuple) schemas for fixed-shape
+	// heterogeneous arrays instead of collapsing them to a single items schema.
+	InferTuples bool `yaml:"inferTuples"`
+
+	// DisallowUnevaluatedProperties closes every generated object schema with
+	// "unevaluatedProperties: false". Only takes effect on 2019-09/2020-12.
+	DisallowUnevaluatedProperties bool `yaml:"disallowUnevaluatedProperties"`
+
+	// SpecializeForHelm replaces recognized Helm config blocks (image,
+	// resources, probes, service, ingress, ...) with a schema hand-tuned for
+	// that idiom. See DisabledRecognizers to turn individual ones off.
+	SpecializeForHelm bool `yaml:"specializeForHelm"`
+
+	// DisabledRecognizers lists recognizer names (e.g. "image", "resources")
+	// to skip when SpecializeForHelm is set.
+	DisabledRecognizers []string `yaml:"disabledRecognizers"`
+
+	// Merge, when true and OutputFile already exists, reconciles it with
+	// the freshly generated schema via jsonschema.MergeSchemas instead of
+	// overwriting it outright, preserving hand-edits and marking vanished
+	// properties deprecated instead of dropping them.
+	Merge bool `yaml:"merge"`
+
+	// Overrides holds user-supplied schema fragments keyed by dotted Helm
+	// path (e.g. "image.tag"), merged into the generated schema after
+	// inference. This lets a config file (see LoadConfig) pin constraints,
+	// enums, patterns, and descriptions that inference can't determine from
+	// values.yaml alone.
+	Overrides map[string]SchemaOverride `yaml:"overrides"`
+
+	// ConfigFile points to a YAML config file supplying defaults for the
+	// fields above; CLI flags always take precedence over values loaded
+	// from it. Not itself settable from within a config file.
+	ConfigFile string `yaml:"-"`
+
+	// NoCache disables the on-disk schema cache, forcing full generation
+	// even when an identical input file was already processed with
+	// identical options.
+	NoCache bool `yaml:"noCache"`
+
+	// CacheDir overrides where cached schemas are stored. Defaults to
+	// $XDG_CACHE_HOME/helm-schema-gen (or the OS cache dir) when empty.
+	CacheDir string `yaml:"cacheDir"`
+
+	// CacheMaxAge, when non-empty, is parsed as a time.Duration; a cache
+	// entry older than it is treated as a miss. Empty means entries never
+	// expire on their own.
+	CacheMaxAge string `yaml:"cacheMaxAge"`
+
+	// Incremental generates the schema one top-level values.yaml key at a
+	// time, reusing jsonschema.SchemaCache entries for keys whose subtree and the
+	// generator options are unchanged since the last run, instead of the
+	// whole-document cache GenerateFromYAMLCached uses.
+	Incremental bool `yaml:"incremental"`
+
+	// PluginsDir is the directory scanned for plugin subdirectories (each
+	// holding a plugin.yaml manifest) to run against the generated schema.
+	// Falls back to $HELM_SCHEMA_GEN_PLUGINS when empty; generation runs no
+	// plugins if neither is set.
+	PluginsDir string `yaml:"pluginsDir"`
+
+	// DisableRules lists best-practices rule IDs (e.g.
+	// "naming/camelcase") to turn off; see pkg/schema-generator.RuleSet
+	// and the "rules list" subcommand for the full set of IDs.
+	DisableRules []string `yaml:"-"`
+
+	// EnableOnlyRules, if non-empty, disables every best-practices rule
+	// whose ID isn't listed.
+	EnableOnlyRules []string `yaml:"-"`
+
+	// BestPracticesRules configures the best-practices RuleSet beyond
+	// simple enable/disable: per-rule severity overrides, threshold
+	// tuning (e.g. max-nesting-depth), and inline custom regex rules. Set
+	// from the config file's "bestPracticesRules" key; see RulesConfig.
+	BestPracticesRules RulesConfig `yaml:"bestPracticesRules"`
+
+	// Jobs is the number of charts processed concurrently in batch mode
+	// (opts.InputFile names a directory or glob pattern, however many
+	// charts it matches). Defaults to runtime.NumCPU(). Ignored for
+	// single-file runs.
+	Jobs int `yaml:"jobs"`
+
+	// ReportFormat selects how batch mode renders its aggregated
+	// best-practices report: table, json, sarif, or junit. See
+	// pkg/report.ParseFormat. Ignored for single-chart runs, which always
+	// print the plain-text format generateAndWriteSchema has always used.
+	ReportFormat string `yaml:"reportFormat"`
 
 	// Application options
-	Verbose bool
-	Debug   bool
+	Verbose bool `yaml:"verbose"`
+	Debug   bool `yaml:"debug"`
 }
 
 // DefaultOptions returns the default configuration options
 func DefaultOptions() *Options {
 	return &Options{
-		InputFile:             "values.yaml",
-		OutputFile:            "values.schema.json",
-		SchemaVersion:         string(jsonschema.Draft07),
-		Title:                 "Helm Values Schema",
-		RequireByDefault:      false,
-		IncludeExamples:       true,
-		ExtractDescriptions:   true,
-		ValidateBestPractices: false,
-		Verbose:               false,
-		Debug:                 false,
+		InputFile:                     "values.yaml",
+		OutputFile:                    "values.schema.json",
+		SchemaVersion:                 string(jsonschema.Draft07),
+		Draft:                         "",
+		Title:                         "Helm Values Schema",
+		RulesFile:                     "",
+		ReplaceRules:                  false,
+		RequireByDefault:              false,
+		IncludeExamples:               true,
+		ExtractDescriptions:           true,
+		ValidateBestPractices:         false,
+		InferTuples:                   false,
+		DisallowUnevaluatedProperties: false,
+		SpecializeForHelm:             false,
+		DisabledRecognizers:           nil,
+		Merge:                         false,
+		ConfigFile:                    "",
+		NoCache:                       false,
+		CacheDir:                      "",
+		CacheMaxAge:                   "",
+		Incremental:                   false,
+		PluginsDir:                    "",
+		DisableRules:                  nil,
+		EnableOnlyRules:               nil,
+		Jobs:                          runtime.NumCPU(),
+		ReportFormat:                  string(report.Table),
+		Verbose:                       false,
+		Debug:                         false,
 	}
 }
 
@@ -62,6 +186,9 @@ func NewRootCommand() *cobra.Command {
 These schemas can be used for validation and providing better IDE support.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
+			if err := applyConfigFile(cmd, opts); err != nil {
+				return err
+			}
 			return runGenerateCommand(ctx, opts)
 		},
 	}
@@ -69,25 +196,133 @@ These schemas can be used for validation and providing better IDE support.`,
 	// Add flags for input/output files
 	rootCmd.Flags().StringVarP(&opts.InputFile, "file", "f", opts.InputFile, "Input values.yaml file")
 	rootCmd.Flags().StringVarP(&opts.OutputFile, "output", "o", opts.OutputFile, "Output schema file")
+	rootCmd.Flags().StringVar(&opts.ConfigFile, "config", opts.ConfigFile, "Path to a YAML config file providing defaults for these flags (defaults to .helm-schema-gen.yaml or schema.yaml if present)")
 
 	// Add schema generation flags
 	rootCmd.Flags().StringVar(&opts.SchemaVersion, "schema-version", opts.SchemaVersion, "JSON Schema version to use")
+	rootCmd.Flags().StringVar(&opts.Draft, "draft", opts.Draft, "Shorthand JSON Schema draft to use (4, 6, 7, 2019, 2020); overrides --schema-version when set")
 	rootCmd.Flags().StringVar(&opts.Title, "title", opts.Title, "Schema title")
 	rootCmd.Flags().StringVar(&opts.Description, "description", opts.Description, "Schema description")
+	rootCmd.Flags().StringVar(&opts.RulesFile, "rules", opts.RulesFile, "Path to a YAML/JSON file of custom pattern rules for multi-type detection")
+	rootCmd.Flags().BoolVar(&opts.ReplaceRules, "replace-rules", opts.ReplaceRules, "Use only the --rules file, ignoring the built-in pattern rules")
 
 	// Add validation options
 	rootCmd.Flags().BoolVar(&opts.RequireByDefault, "require-all", opts.RequireByDefault, "Make all properties required")
 	rootCmd.Flags().BoolVar(&opts.IncludeExamples, "include-examples", opts.IncludeExamples, "Include examples from values")
 	rootCmd.Flags().BoolVar(&opts.ExtractDescriptions, "extract-descriptions", opts.ExtractDescriptions, "Extract descriptions from comments")
 	rootCmd.Flags().BoolVar(&opts.ValidateBestPractices, "validate", opts.ValidateBestPractices, "Validate schema against Helm best practices")
+	rootCmd.Flags().BoolVar(&opts.InferTuples, "infer-tuples", opts.InferTuples, "Emit positional tuple schemas for fixed-shape heterogeneous arrays")
+	rootCmd.Flags().BoolVar(&opts.DisallowUnevaluatedProperties, "disallow-unevaluated-properties", opts.DisallowUnevaluatedProperties, "Set unevaluatedProperties: false on every object schema (2019-09/2020-12 only)")
+	rootCmd.Flags().BoolVar(&opts.Merge, "merge", opts.Merge, "If the output schema already exists, merge hand-edits into the newly generated schema instead of overwriting it")
+	rootCmd.Flags().BoolVar(&opts.SpecializeForHelm, "specialize-helm", opts.SpecializeForHelm, "Replace recognized Helm config blocks (image, resources, probes, service, ingress, ...) with hand-tuned schemas")
+	rootCmd.Flags().StringSliceVar(&opts.DisabledRecognizers, "disable-recognizers", opts.DisabledRecognizers, "Comma-separated recognizer names to skip when --specialize-helm is set, e.g. image,resources")
+	rootCmd.Flags().BoolVar(&opts.NoCache, "no-cache", opts.NoCache, "Disable the on-disk schema cache, always regenerating from scratch")
+	rootCmd.Flags().StringVar(&opts.CacheDir, "cache-dir", opts.CacheDir, "Directory to store cached schemas in (defaults to $XDG_CACHE_HOME/helm-schema-gen)")
+	rootCmd.Flags().StringVar(&opts.CacheMaxAge, "cache-max-age", opts.CacheMaxAge, "Treat cache entries older than this duration (e.g. 24h) as a miss; empty means entries never expire")
+	rootCmd.Flags().BoolVar(&opts.Incremental, "incremental", opts.Incremental, "Cache and reuse one schema fragment per top-level values.yaml key instead of the whole document")
+	rootCmd.Flags().StringVar(&opts.PluginsDir, "plugins-dir", opts.PluginsDir, "Directory of plugins to run against the generated schema (defaults to $HELM_SCHEMA_GEN_PLUGINS)")
+	rootCmd.Flags().StringSliceVar(&opts.DisableRules, "disable-rule", opts.DisableRules, "Best-practices rule ID to disable (repeatable), e.g. --disable-rule naming/camelcase")
+	rootCmd.Flags().StringSliceVar(&opts.EnableOnlyRules, "enable-only", opts.EnableOnlyRules, "Comma-separated best-practices rule IDs to run, disabling every other rule")
+
+	// Add batch mode options, used when --file names a directory or a glob
+	// pattern rather than a single values.yaml.
+	rootCmd.Flags().IntVar(&opts.Jobs, "jobs", opts.Jobs, "Number of charts to process concurrently in batch mode")
+	rootCmd.Flags().StringVar(&opts.ReportFormat, "report-format", opts.ReportFormat, "Batch mode report format: table, json, sarif, or junit")
 
 	// Add application options
 	rootCmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", opts.Verbose, "Enable verbose output")
 	rootCmd.Flags().BoolVar(&opts.Debug, "debug", opts.Debug, "Enable debug output")
 
+	rootCmd.AddCommand(newValidateCommand())
+	rootCmd.AddCommand(newCoverageCommand())
+	rootCmd.AddCommand(newExampleCommand())
+	rootCmd.AddCommand(newPluginCommand())
+	rootCmd.AddCommand(newWatchCommand())
+	rootCmd.AddCommand(newLSPCommand())
+	rootCmd.AddCommand(newRulesCommand())
+
 	return rootCmd
 }
 
+// newValidateCommand creates the "validate" subcommand, which checks a
+// values file against a generated JSON Schema.
+func newValidateCommand() *cobra.Command {
+	var schemaFile string
+	var strict bool
+
+	validateCmd := &cobra.Command{
+		Use:   "validate [values-file]",
+		Short: "Validate a values file against a JSON Schema",
+		Long:  `validate checks a Helm values.yaml file against a JSON Schema produced by helm-schema-gen (or any compatible schema), reporting every structural validation failure it finds.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			return runValidateCommand(ctx, schemaFile, args[0], strict)
+		},
+	}
+
+	validateCmd.Flags().StringVar(&schemaFile, "schema", "values.schema.json", "Path to the JSON Schema file to validate against")
+	validateCmd.Flags().BoolVar(&strict, "strict", false, "Exit with a non-zero status if any validation errors are found, for use in CI")
+
+	return validateCmd
+}
+
+// newCoverageCommand creates the "coverage" subcommand, which reports how
+// well a set of real values.yaml files exercise a generated JSON Schema.
+func newCoverageCommand() *cobra.Command {
+	var schemaFile string
+	var jsonOutput bool
+
+	coverageCmd := &cobra.Command{
+		Use:   "coverage [values-file]...",
+		Short: "Report schema coverage against real values files",
+		Long:  `coverage checks a JSON Schema against one or more real values.yaml files (e.g., production, staging, examples), reporting which schema properties are actually used, which required fields are never set, and which values paths have no corresponding schema property.`,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			return runCoverageCommand(ctx, schemaFile, args, jsonOutput)
+		},
+	}
+
+	coverageCmd.Flags().StringVar(&schemaFile, "schema", "values.schema.json", "Path to the JSON Schema file to check coverage against")
+	coverageCmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the coverage report as machine-readable JSON instead of a human-readable summary")
+
+	return coverageCmd
+}
+
+// newExampleCommand creates the "example" subcommand, which renders an
+// example values.yaml document back from a generated JSON Schema.
+func newExampleCommand() *cobra.Command {
+	var schemaFile string
+	var outputFile string
+	var onlyRequired bool
+	var includeComments bool
+	var maxDepth int
+
+	exampleCmd := &cobra.Command{
+		Use:   "example",
+		Short: "Generate an example values.yaml from a JSON Schema",
+		Long:  `example reads a JSON Schema produced by helm-schema-gen and renders an example Helm values.yaml document from it, picking each property's default, first example, first enum value, or a format-derived placeholder.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			return runExampleCommand(ctx, schemaFile, outputFile, examplegen.Options{
+				OnlyRequired:    onlyRequired,
+				IncludeComments: includeComments,
+				MaxDepth:        maxDepth,
+			})
+		},
+	}
+
+	exampleCmd.Flags().StringVarP(&schemaFile, "schema", "s", "values.schema.json", "Path to the JSON Schema file to generate an example from")
+	exampleCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the example values.yaml to this file instead of stdout")
+	exampleCmd.Flags().BoolVar(&onlyRequired, "only-required", false, "Only include properties listed as required, at every nesting level")
+	exampleCmd.Flags().BoolVar(&includeComments, "comments", false, "Emit each property's description as a comment above it")
+	exampleCmd.Flags().IntVar(&maxDepth, "max-depth", 0, "Maximum nesting depth to expand before collapsing to {} or [] (0 means unlimited)")
+
+	return exampleCmd
+}
+
 // ExecuteCLI runs the CLI application
 func ExecuteCLI() error {
 	rootCmd := NewRootCommand()
@@ -112,100 +347,81 @@ func validatePath(ctx context.Context, path string) error {
 	return nil
 }
 
-// runGenerateCommand handles the main schema generation logic
-func runGenerateCommand(ctx context.Context, opts *Options) error {
-	logger := logging.WithComponent(ctx, "cli")
-
-	// Resolve input file path
-	inputPath := opts.InputFile
-	if !filepath.IsAbs(inputPath) {
-		cwd, err := os.Getwd()
-		if err != nil {
-			return fmt.Errorf("failed to get current directory: %w", err)
-		}
-		inputPath = filepath.Join(cwd, inputPath)
+// resolvePluginsDir returns opts.PluginsDir, falling back to the
+// $HELM_SCHEMA_GEN_PLUGINS environment variable, or "" if neither is set.
+func resolvePluginsDir(opts *Options) string {
+	if opts.PluginsDir != "" {
+		return opts.PluginsDir
 	}
+	return os.Getenv("HELM_SCHEMA_GEN_PLUGINS")
+}
 
-	// Validate input path for security
-	if err := validatePath(ctx, inputPath); err != nil {
-		return fmt.Errorf("invalid input file path: %w", err)
+// runGenerateCommand handles the main schema generation logic, dispatching
+// to the batch path when opts.InputFile names a directory or glob pattern
+// (even one matching a single chart) instead of a single values.yaml.
+func runGenerateCommand(ctx context.Context, opts *Options) error {
+	isBatch, err := isBatchInput(opts.InputFile)
+	if err != nil {
+		return err
 	}
-
-	// Check if input file exists
-	if _, err := os.Stat(inputPath); os.IsNotExist(err) {
-		return fmt.Errorf("input file not found: %s", inputPath)
+	if isBatch {
+		return runBatchGenerateCommand(ctx, opts)
 	}
 
-	// Read input file - #nosec G304 is used because we've validated the path
-	yamlData, err := os.ReadFile(inputPath) // #nosec G304
+	_, err = generateAndWriteSchema(ctx, opts)
+	return err
+}
+
+// generateAndWriteSchema resolves opts.InputFile/opts.OutputFile to absolute
+// paths, runs processChart, prints the best-practices/plugin validation
+// report in the single-chart format, writes the schema to opts.OutputFile,
+// and returns the final schema so callers like the watch command can diff it
+// against a previous run.
+func generateAndWriteSchema(ctx context.Context, opts *Options) (*jsonschema.Schema, error) {
+	logger := logging.WithComponent(ctx, "cli")
+
+	inputPath, err := resolveAbsPath(ctx, opts.InputFile)
 	if err != nil {
-		return fmt.Errorf("failed to read input file: %w", err)
+		return nil, fmt.Errorf("invalid input file path: %w", err)
 	}
-
-	if opts.Verbose {
-		logger.InfoContext(ctx, "read values file successfully", "path", inputPath, "size", len(yamlData))
+	if _, err := os.Stat(inputPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("input file not found: %s", inputPath)
 	}
 
-	// Configure generator options
-	genOpts := jsonschema.GeneratorOptions{
-		SchemaVersion:       jsonschema.SchemaVersion(opts.SchemaVersion),
-		Title:               opts.Title,
-		Description:         opts.Description,
-		RequireByDefault:    opts.RequireByDefault,
-		IncludeExamples:     opts.IncludeExamples,
-		ExtractDescriptions: opts.ExtractDescriptions,
-		Debug:               opts.Debug,
+	outputPath, err := resolveAbsPath(ctx, opts.OutputFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output file path: %w", err)
 	}
 
-	// Create schema generator
-	generator := jsonschema.NewGenerator(genOpts)
-
-	// Generate schema from YAML data
-	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	schema, issues, err := processChart(ctx, inputPath, outputPath, opts)
 	if err != nil {
-		return fmt.Errorf("schema generation failed: %w", err)
+		return nil, err
 	}
 
-	// Run validation if requested
 	if opts.ValidateBestPractices {
-		issues := jsonschema.ValidateHelmBestPractices(schema)
 		if len(issues) > 0 {
-			formattedIssues := jsonschema.FormatValidationIssues(issues)
 			fmt.Println("\nHelm Best Practices Validation:")
-			fmt.Println(formattedIssues)
+			fmt.Println(jsonschema.FormatValidationIssues(issues))
 		} else if opts.Verbose {
 			logger.InfoContext(ctx, "no best practice issues found")
 		}
-	}
-
-	// Resolve output file path
-	outputPath := opts.OutputFile
-	if !filepath.IsAbs(outputPath) {
-		cwd, err := os.Getwd()
-		if err != nil {
-			logger.ErrorContext(ctx, "failed to get current directory", "error", err)
-			return logging.LogError(ctx, err, "failed to get current directory")
-		}
-		outputPath = filepath.Join(cwd, outputPath)
-	}
-
-	// Validate output path for security
-	if err := validatePath(ctx, outputPath); err != nil {
-		return fmt.Errorf("invalid output file path: %w", err)
+	} else if len(issues) > 0 {
+		fmt.Println("\nPlugin Validation:")
+		fmt.Println(jsonschema.FormatValidationIssues(issues))
 	}
 
 	// Ensure output directory exists
 	outputDir := filepath.Dir(outputPath)
 	if err := os.MkdirAll(outputDir, 0750); err != nil {
 		logger.ErrorContext(ctx, "failed to create output directory", "error", err)
-		return logging.LogError(ctx, err, "failed to create output directory")
+		return nil, logging.LogError(ctx, err, "failed to create output directory")
 	}
 
 	// Create output file - #nosec G304 is used because we've validated the path
 	f, err := os.Create(outputPath) // #nosec G304
 	if err != nil {
 		logger.ErrorContext(ctx, "failed to create output file", "error", err)
-		return logging.LogError(ctx, err, "failed to create output file")
+		return nil, logging.LogError(ctx, err, "failed to create output file")
 	}
 	defer f.Close()
 
@@ -213,7 +429,7 @@ func runGenerateCommand(ctx context.Context, opts *Options) error {
 	_, err = f.WriteString(schema.String())
 	if err != nil {
 		logger.ErrorContext(ctx, "failed to write schema to file", "error", err)
-		return logging.LogError(ctx, err, "failed to write schema to file")
+		return nil, logging.LogError(ctx, err, "failed to write schema to file")
 	}
 
 	if opts.Verbose {
@@ -222,5 +438,335 @@ func runGenerateCommand(ctx context.Context, opts *Options) error {
 		logger.InfoContext(ctx, "schema generated successfully", "output", outputPath)
 	}
 
+	return schema, nil
+}
+
+// resolveAbsPath resolves path relative to the current working directory
+// (path is returned unchanged if already absolute) and validates it.
+func resolveAbsPath(ctx context.Context, path string) (string, error) {
+	abs := path
+	if !filepath.IsAbs(abs) {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to get current directory: %w", err)
+		}
+		abs = filepath.Join(cwd, abs)
+	}
+	if err := validatePath(ctx, abs); err != nil {
+		return "", err
+	}
+	return abs, nil
+}
+
+// processChart runs the full single-chart pipeline - generate from
+// inputPath's values.yaml, merge with any existing outputPath schema,
+// apply config-file overrides, run plugins, and (if requested) check
+// best practices - without printing or writing anything, so it can be
+// reused by both the single-file and batch generation paths. The returned
+// issues combine best-practices issues (when opts.ValidateBestPractices is
+// set) and plugin validator issues.
+func processChart(ctx context.Context, inputPath, outputPath string, opts *Options) (*jsonschema.Schema, []jsonschema.ValidationIssue, error) {
+	logger := logging.WithComponent(ctx, "cli")
+
+	// Read input file - #nosec G304 is used because we've validated the path
+	yamlData, err := os.ReadFile(inputPath) // #nosec G304
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	if opts.Verbose {
+		logger.InfoContext(ctx, "read values file successfully", "path", inputPath, "size", len(yamlData))
+	}
+
+	// Resolve the schema version to generate, letting --draft override
+	// --schema-version when given.
+	schemaVersion := jsonschema.SchemaVersion(opts.SchemaVersion)
+	if opts.Draft != "" {
+		version, err := jsonschema.ParseDraftShorthand(opts.Draft)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --draft: %w", err)
+		}
+		schemaVersion = version
+	}
+
+	// Configure generator options
+	genOpts := jsonschema.GeneratorOptions{
+		SchemaVersion:                 schemaVersion,
+		Title:                         opts.Title,
+		Description:                   opts.Description,
+		RequireByDefault:              opts.RequireByDefault,
+		IncludeExamples:               opts.IncludeExamples,
+		ExtractDescriptions:           opts.ExtractDescriptions,
+		ReplacePatternRules:           opts.ReplaceRules,
+		InferTuples:                   opts.InferTuples,
+		DisallowUnevaluatedProperties: opts.DisallowUnevaluatedProperties,
+		SpecializeForHelm:             opts.SpecializeForHelm,
+		DisabledRecognizers:           opts.DisabledRecognizers,
+		Debug:                         opts.Debug,
+	}
+
+	// Create schema generator, loading custom pattern rules if requested
+	generator, err := jsonschema.NewGeneratorFromConfig(genOpts, opts.RulesFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create schema generator: %w", err)
+	}
+
+	// Generate schema from YAML data, going through the on-disk cache unless
+	// disabled so that an unchanged input file with unchanged options is a
+	// near-instant no-op.
+	var schema *jsonschema.Schema
+	if opts.NoCache {
+		schema, err = generator.GenerateFromYAML(ctx, yamlData)
+	} else {
+		cacheDir := opts.CacheDir
+		if cacheDir == "" {
+			cacheDir, err = jsonschema.DefaultCacheDir()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to resolve cache directory: %w", err)
+			}
+		}
+		cache := jsonschema.NewSchemaCache(cacheDir)
+		if opts.CacheMaxAge != "" {
+			cache.MaxAge, err = time.ParseDuration(opts.CacheMaxAge)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid --cache-max-age: %w", err)
+			}
+		}
+		if opts.Incremental {
+			schema, err = generator.GenerateFromYAMLIncremental(ctx, yamlData, cache)
+		} else {
+			schema, err = generator.GenerateFromYAMLCached(ctx, yamlData, cache)
+		}
+		logger.InfoContext(ctx, "schema cache stats", "hits", cache.Hits, "misses", cache.Misses)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("schema generation failed: %w", err)
+	}
+
+	// With --merge, reconcile against the existing output schema (if any)
+	// instead of overwriting it outright.
+	if opts.Merge {
+		schema, err = mergeWithExistingSchema(ctx, outputPath, schema)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Merge any per-path schema fragments from the config file
+	if len(opts.Overrides) > 0 {
+		applySchemaOverrides(ctx, schema, opts.Overrides)
+	}
+
+	// Run plugins, if any are configured: transform plugins may rewrite the
+	// schema, validator plugins contribute additional issues to report
+	// alongside the best-practices check below.
+	var pluginIssues []jsonschema.ValidationIssue
+	if pluginsDir := resolvePluginsDir(opts); pluginsDir != "" {
+		plugins, err := plugin.FindPlugins(pluginsDir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to discover plugins: %w", err)
+		}
+		if len(plugins) > 0 {
+			schema, pluginIssues, err = plugin.RunAll(ctx, plugins, schema)
+			if err != nil {
+				return nil, nil, fmt.Errorf("plugin run failed: %w", err)
+			}
+			logger.InfoContext(ctx, "ran plugins", "count", len(plugins))
+		}
+	}
+
+	issues := pluginIssues
+	if opts.ValidateBestPractices {
+		ruleSet, err := buildRuleSet(opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to build best-practices rule set: %w", err)
+		}
+		issues = append(ruleSet.Validate(schema), pluginIssues...)
+	}
+
+	return schema, issues, nil
+}
+
+// mergeWithExistingSchema loads the schema already at outputPath, if any,
+// and reconciles it with generated via jsonschema.MergeSchemas, printing
+// any merge conflicts it reports. If outputPath doesn't exist yet, there's
+// nothing to merge, so generated is returned unchanged.
+func mergeWithExistingSchema(ctx context.Context, outputPath string, generated *jsonschema.Schema) (*jsonschema.Schema, error) {
+	logger := logging.WithComponent(ctx, "cli")
+
+	existingData, err := os.ReadFile(outputPath) // #nosec G304
+	if os.IsNotExist(err) {
+		logger.InfoContext(ctx, "no existing output schema found, nothing to merge", "output", outputPath)
+		return generated, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read existing output schema for merge: %w", err)
+	}
+
+	var existing jsonschema.Schema
+	if err := json.Unmarshal(existingData, &existing); err != nil {
+		return nil, fmt.Errorf("failed to parse existing output schema for merge: %w", err)
+	}
+
+	merged, conflicts := jsonschema.MergeSchemas(&existing, generated, jsonschema.MergeOptions{})
+	if len(conflicts) > 0 {
+		fmt.Println("\nSchema Merge Conflicts:")
+		fmt.Println(jsonschema.FormatValidationIssues(jsonschema.MergeConflictIssues(conflicts)))
+	}
+
+	return merged, nil
+}
+
+// runValidateCommand handles the "validate" subcommand, checking a values
+// file against a JSON Schema and reporting any failures. With strict set,
+// it returns an error (and a non-zero exit status) when any Error-level
+// issue is found, so the command can gate CI; without it, issues are only
+// reported.
+func runValidateCommand(ctx context.Context, schemaPath string, valuesPath string, strict bool) error {
+	logger := logging.WithComponent(ctx, "cli")
+
+	if err := validatePath(ctx, schemaPath); err != nil {
+		return fmt.Errorf("invalid schema file path: %w", err)
+	}
+	if err := validatePath(ctx, valuesPath); err != nil {
+		return fmt.Errorf("invalid values file path: %w", err)
+	}
+
+	schemaData, err := os.ReadFile(schemaPath) // #nosec G304
+	if err != nil {
+		return fmt.Errorf("failed to read schema file: %w", err)
+	}
+
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(schemaData, &schema); err != nil {
+		return fmt.Errorf("failed to parse schema file: %w", err)
+	}
+
+	valuesData, err := os.ReadFile(valuesPath) // #nosec G304
+	if err != nil {
+		return fmt.Errorf("failed to read values file: %w", err)
+	}
+
+	var values any
+	if err := yaml.Unmarshal(valuesData, &values); err != nil {
+		return fmt.Errorf("failed to parse values file: %w", err)
+	}
+
+	issues := jsonschema.Validate(&schema, values)
+
+	if len(issues) == 0 {
+		logger.InfoContext(ctx, "values file is valid", "schema", schemaPath, "values", valuesPath)
+		fmt.Printf("%s is valid against %s\n", valuesPath, schemaPath)
+		return nil
+	}
+
+	fmt.Printf("%s failed validation against %s:\n\n", valuesPath, schemaPath)
+	fmt.Println(jsonschema.FormatValidationIssues(issues))
+
+	if strict {
+		return fmt.Errorf("values file failed validation with %d issue(s)", len(issues))
+	}
+
+	return nil
+}
+
+// runCoverageCommand handles the "coverage" subcommand, computing and
+// reporting how well valuesPaths exercise the schema at schemaPath.
+func runCoverageCommand(ctx context.Context, schemaPath string, valuesPaths []string, jsonOutput bool) error {
+	if err := validatePath(ctx, schemaPath); err != nil {
+		return fmt.Errorf("invalid schema file path: %w", err)
+	}
+
+	schemaData, err := os.ReadFile(schemaPath) // #nosec G304
+	if err != nil {
+		return fmt.Errorf("failed to read schema file: %w", err)
+	}
+
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(schemaData, &schema); err != nil {
+		return fmt.Errorf("failed to parse schema file: %w", err)
+	}
+
+	docs := make([]map[string]any, 0, len(valuesPaths))
+	for _, valuesPath := range valuesPaths {
+		if err := validatePath(ctx, valuesPath); err != nil {
+			return fmt.Errorf("invalid values file path: %w", err)
+		}
+
+		valuesData, err := os.ReadFile(valuesPath) // #nosec G304
+		if err != nil {
+			return fmt.Errorf("failed to read values file: %w", err)
+		}
+
+		doc, err := jsonschema.LoadValuesDocument(valuesData)
+		if err != nil {
+			return fmt.Errorf("failed to parse values file '%s': %w", valuesPath, err)
+		}
+		docs = append(docs, doc)
+	}
+
+	report := jsonschema.ComputeCoverage(&schema, docs)
+
+	if jsonOutput {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal coverage report: %w", err)
+		}
+		fmt.Println(string(encoded))
+		return nil
+	}
+
+	fmt.Printf("Coverage against %d values file(s):\n", report.DocumentCount)
+	fmt.Printf("  %d schema path(s), %d uncovered required, %d unknown value(s)\n\n",
+		len(report.Paths), len(report.UncoveredRequired), len(report.UnknownPaths))
+
+	if issues := report.Issues(); len(issues) > 0 {
+		fmt.Println(jsonschema.FormatValidationIssues(issues))
+	} else {
+		fmt.Println("every schema path is covered and every value is recognized")
+	}
+
+	return nil
+}
+
+// runExampleCommand handles the "example" subcommand, rendering an example
+// values.yaml document from the schema at schemaPath and writing it to
+// outputPath, or to stdout when outputPath is empty.
+func runExampleCommand(ctx context.Context, schemaPath string, outputPath string, opts examplegen.Options) error {
+	logger := logging.WithComponent(ctx, "cli")
+
+	if err := validatePath(ctx, schemaPath); err != nil {
+		return fmt.Errorf("invalid schema file path: %w", err)
+	}
+
+	schemaData, err := os.ReadFile(schemaPath) // #nosec G304
+	if err != nil {
+		return fmt.Errorf("failed to read schema file: %w", err)
+	}
+
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(schemaData, &schema); err != nil {
+		return fmt.Errorf("failed to parse schema file: %w", err)
+	}
+
+	example, err := examplegen.Generate(&schema, opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate example values: %w", err)
+	}
+
+	if outputPath == "" {
+		fmt.Print(string(example))
+		return nil
+	}
+
+	if err := validatePath(ctx, outputPath); err != nil {
+		return fmt.Errorf("invalid output file path: %w", err)
+	}
+
+	if err := os.WriteFile(outputPath, example, 0600); err != nil {
+		return fmt.Errorf("failed to write example values file: %w", err)
+	}
+
+	logger.InfoContext(ctx, "example values generated successfully", "output", outputPath)
 	return nil
 }
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	examplegen "github.com/dc-tec/helm-schema-gen/pkg/example-generator"
 )
 
 func TestDefaultOptions(t *testing.T) {
@@ -176,6 +178,103 @@ func setupTestFiles(t *testing.T) (string, string, func()) {
 	return valuesPath, outputPath, cleanup
 }
 
+func TestRunExampleCommand(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaPath := filepath.Join(tempDir, "values.schema.json")
+	schemaJSON := `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "replicaCount": {"type": "integer", "default": 1},
+    "image": {
+      "type": "object",
+      "properties": {
+        "repository": {"type": "string", "examples": ["nginx"]}
+      }
+    }
+  }
+}`
+	if err := os.WriteFile(schemaPath, []byte(schemaJSON), 0644); err != nil {
+		t.Fatalf("failed to write schema file: %v", err)
+	}
+
+	outputPath := filepath.Join(tempDir, "example-values.yaml")
+	ctx := context.Background()
+
+	if err := runExampleCommand(ctx, schemaPath, outputPath, examplegen.Options{}); err != nil {
+		t.Fatalf("runExampleCommand failed: %v", err)
+	}
+
+	out, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read generated example file: %v", err)
+	}
+
+	want := "image:\n  repository: \"nginx\"\nreplicaCount: 1\n"
+	if string(out) != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestRunValidateCommand(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaPath := filepath.Join(tempDir, "values.schema.json")
+	schemaJSON := `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "replicaCount": {"type": "integer"}
+  }
+}`
+	if err := os.WriteFile(schemaPath, []byte(schemaJSON), 0644); err != nil {
+		t.Fatalf("failed to write schema file: %v", err)
+	}
+
+	valuesPath := filepath.Join(tempDir, "values.yaml")
+	if err := os.WriteFile(valuesPath, []byte("replicaCount: \"not-a-number\"\n"), 0644); err != nil {
+		t.Fatalf("failed to write values file: %v", err)
+	}
+
+	ctx := context.Background()
+
+	if err := runValidateCommand(ctx, schemaPath, valuesPath, false); err != nil {
+		t.Errorf("expected no error without --strict, got %v", err)
+	}
+
+	if err := runValidateCommand(ctx, schemaPath, valuesPath, true); err == nil {
+		t.Error("expected an error with --strict on failing values")
+	}
+}
+
+func TestRunValidateCommand_PassingValuesStrict(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaPath := filepath.Join(tempDir, "values.schema.json")
+	schemaJSON := `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "replicaCount": {"type": "integer"}
+  }
+}`
+	if err := os.WriteFile(schemaPath, []byte(schemaJSON), 0644); err != nil {
+		t.Fatalf("failed to write schema file: %v", err)
+	}
+
+	valuesPath := filepath.Join(tempDir, "values.yaml")
+	if err := os.WriteFile(valuesPath, []byte("replicaCount: 3\n"), 0644); err != nil {
+		t.Fatalf("failed to write values file: %v", err)
+	}
+
+	ctx := context.Background()
+
+	if err := runValidateCommand(ctx, schemaPath, valuesPath, true); err != nil {
+		t.Errorf("expected no error for passing values with --strict, got %v", err)
+	}
+}
+
 func TestRunGenerateCommand(t *testing.T) {
 	// Skip actual schema generation and validation in CI
 	// This is simplified for the test setup
@@ -215,3 +314,50 @@ func TestRunGenerateCommand(t *testing.T) {
 		t.Errorf("Output file was not created at %s", outputPath)
 	}
 }
+
+func TestRunGenerateCommand_MergePreservesHandEditsAndDeprecatesVanishedProperties(t *testing.T) {
+	tempDir := t.TempDir()
+
+	outputPath := filepath.Join(tempDir, "values.schema.json")
+	existingSchema := `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "replicaCount": {"type": "integer", "description": "Number of pod replicas to run"},
+    "legacyFlag": {"type": "boolean"}
+  }
+}`
+	if err := os.WriteFile(outputPath, []byte(existingSchema), 0644); err != nil {
+		t.Fatalf("failed to write existing output schema: %v", err)
+	}
+
+	inputPath := filepath.Join(tempDir, "values.yaml")
+	if err := os.WriteFile(inputPath, []byte("replicaCount: 3\n"), 0644); err != nil {
+		t.Fatalf("failed to write values file: %v", err)
+	}
+
+	opts := &Options{
+		InputFile:     inputPath,
+		OutputFile:    outputPath,
+		SchemaVersion: "http://json-schema.org/draft-07/schema#",
+		Title:         "Test Schema",
+		Merge:         true,
+	}
+
+	ctx := context.Background()
+	if err := runGenerateCommand(ctx, opts); err != nil {
+		t.Fatalf("runGenerateCommand returned unexpected error: %v", err)
+	}
+
+	mergedData, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read merged output schema: %v", err)
+	}
+
+	if !bytes.Contains(mergedData, []byte("Number of pod replicas to run")) {
+		t.Error("expected merged schema to preserve the hand-authored description")
+	}
+	if !bytes.Contains(mergedData, []byte(`"legacyFlag"`)) || !bytes.Contains(mergedData, []byte(`"deprecated": true`)) {
+		t.Error("expected merged schema to keep legacyFlag and mark it deprecated")
+	}
+}
@@ -0,0 +1,237 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"strings"
+
+	"github.com/dc-tec/helm-schema-gen/pkg/logging"
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// defaultConfigFileNames lists the config file names looked for (see
+// discoverConfigFile) when --config isn't given, in order of preference.
+var defaultConfigFileNames = []string{".helm-schema-gen.yaml", "schema.yaml"}
+
+// SchemaOverride is a user-supplied schema fragment merged into the
+// generated schema at a specific dotted path (see Options.Overrides and
+// LoadConfig), letting a config file pin constraints, enums, patterns, and
+// descriptions that inference can't determine from values.yaml alone. Only
+// non-zero fields are applied, so an override only needs to set the
+// properties it wants to pin.
+type SchemaOverride struct {
+	Description string   `yaml:"description,omitempty"`
+	Pattern     string   `yaml:"pattern,omitempty"`
+	Format      string   `yaml:"format,omitempty"`
+	Enum        []any    `yaml:"enum,omitempty"`
+	Default     any      `yaml:"default,omitempty"`
+	Examples    []any    `yaml:"examples,omitempty"`
+	Minimum     *float64 `yaml:"minimum,omitempty"`
+	Maximum     *float64 `yaml:"maximum,omitempty"`
+	MinLength   *int     `yaml:"minLength,omitempty"`
+	MaxLength   *int     `yaml:"maxLength,omitempty"`
+}
+
+// applyTo merges the non-zero fields of o into schema.
+func (o SchemaOverride) applyTo(schema *jsonschema.Schema) {
+	if o.Description != "" {
+		schema.Description = o.Description
+	}
+	if o.Pattern != "" {
+		schema.Pattern = o.Pattern
+	}
+	if o.Format != "" {
+		schema.Format = o.Format
+	}
+	if len(o.Enum) > 0 {
+		schema.Enum = o.Enum
+	}
+	if o.Default != nil {
+		schema.Default = o.Default
+	}
+	if len(o.Examples) > 0 {
+		schema.Examples = o.Examples
+	}
+	if o.Minimum != nil {
+		schema.Minimum = o.Minimum
+	}
+	if o.Maximum != nil {
+		schema.Maximum = o.Maximum
+	}
+	if o.MinLength != nil {
+		schema.MinLength = o.MinLength
+	}
+	if o.MaxLength != nil {
+		schema.MaxLength = o.MaxLength
+	}
+}
+
+// LoadConfig reads a YAML config file and returns the Options it describes,
+// layered on top of DefaultOptions so fields the file omits keep their
+// usual defaults. The config may set the fields CLI flags set, except
+// ConfigFile, DisableRules and EnableOnlyRules (rule selection comes from
+// its bestPracticesRules key instead), plus Overrides (per-path schema
+// fragments merged into the generated schema).
+func LoadConfig(path string) (*Options, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- caller-supplied config path
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	opts := DefaultOptions()
+	if err := yaml.Unmarshal(data, opts); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return opts, nil
+}
+
+// discoverConfigFile returns the first of defaultConfigFileNames found by
+// checking startDir, then each of its parent directories in turn up to the
+// filesystem root, mirroring how tools like golangci-lint discover their
+// config. Returns "" if none of them have one.
+func discoverConfigFile(startDir string) string {
+	dir := startDir
+	for {
+		for _, name := range defaultConfigFileNames {
+			candidate := filepath.Join(dir, name)
+			if _, err := os.Stat(candidate); err == nil {
+				return candidate
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// applyConfigFile loads opts.ConfigFile (or, if unset, a config file
+// discovered by walking upward from opts.InputFile's directory) and merges
+// it into opts, skipping any field whose corresponding flag was explicitly
+// set on cmd - flags always win over the config file.
+func applyConfigFile(cmd *cobra.Command, opts *Options) error {
+	configPath := opts.ConfigFile
+	if configPath == "" {
+		startDir := filepath.Dir(opts.InputFile)
+		if startDir == "" {
+			startDir = "."
+		}
+		configPath = discoverConfigFile(startDir)
+		if configPath == "" {
+			return nil
+		}
+	}
+
+	fileOpts, err := LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config file '%s': %w", configPath, err)
+	}
+
+	mergeConfigIntoOptions(cmd, opts, fileOpts)
+	return nil
+}
+
+// mergeConfigIntoOptions copies each field of fileOpts into opts, unless the
+// user explicitly passed the corresponding flag on the command line.
+func mergeConfigIntoOptions(cmd *cobra.Command, opts *Options, fileOpts *Options) {
+	assignStringIfUnchanged(cmd, "file", &opts.InputFile, fileOpts.InputFile)
+	assignStringIfUnchanged(cmd, "output", &opts.OutputFile, fileOpts.OutputFile)
+	assignStringIfUnchanged(cmd, "schema-version", &opts.SchemaVersion, fileOpts.SchemaVersion)
+	assignStringIfUnchanged(cmd, "draft", &opts.Draft, fileOpts.Draft)
+	assignStringIfUnchanged(cmd, "title", &opts.Title, fileOpts.Title)
+	assignStringIfUnchanged(cmd, "description", &opts.Description, fileOpts.Description)
+	assignStringIfUnchanged(cmd, "rules", &opts.RulesFile, fileOpts.RulesFile)
+	assignBoolIfUnchanged(cmd, "replace-rules", &opts.ReplaceRules, fileOpts.ReplaceRules)
+	assignBoolIfUnchanged(cmd, "require-all", &opts.RequireByDefault, fileOpts.RequireByDefault)
+	assignBoolIfUnchanged(cmd, "include-examples", &opts.IncludeExamples, fileOpts.IncludeExamples)
+	assignBoolIfUnchanged(cmd, "extract-descriptions", &opts.ExtractDescriptions, fileOpts.ExtractDescriptions)
+	assignBoolIfUnchanged(cmd, "validate", &opts.ValidateBestPractices, fileOpts.ValidateBestPractices)
+	assignBoolIfUnchanged(cmd, "infer-tuples", &opts.InferTuples, fileOpts.InferTuples)
+	assignBoolIfUnchanged(cmd, "disallow-unevaluated-properties", &opts.DisallowUnevaluatedProperties, fileOpts.DisallowUnevaluatedProperties)
+	assignBoolIfUnchanged(cmd, "specialize-helm", &opts.SpecializeForHelm, fileOpts.SpecializeForHelm)
+	assignStringSliceIfUnchanged(cmd, "disable-recognizers", &opts.DisabledRecognizers, fileOpts.DisabledRecognizers)
+	assignBoolIfUnchanged(cmd, "merge", &opts.Merge, fileOpts.Merge)
+	assignBoolIfUnchanged(cmd, "no-cache", &opts.NoCache, fileOpts.NoCache)
+	assignStringIfUnchanged(cmd, "cache-dir", &opts.CacheDir, fileOpts.CacheDir)
+	assignStringIfUnchanged(cmd, "cache-max-age", &opts.CacheMaxAge, fileOpts.CacheMaxAge)
+	assignBoolIfUnchanged(cmd, "incremental", &opts.Incremental, fileOpts.Incremental)
+	assignStringIfUnchanged(cmd, "plugins-dir", &opts.PluginsDir, fileOpts.PluginsDir)
+	assignIntIfUnchanged(cmd, "jobs", &opts.Jobs, fileOpts.Jobs)
+	assignStringIfUnchanged(cmd, "report-format", &opts.ReportFormat, fileOpts.ReportFormat)
+	assignStringSliceIfUnchanged(cmd, "disable-rule", &opts.DisableRules, fileOpts.DisableRules)
+	assignStringSliceIfUnchanged(cmd, "enable-only", &opts.EnableOnlyRules, fileOpts.EnableOnlyRules)
+	assignBoolIfUnchanged(cmd, "verbose", &opts.Verbose, fileOpts.Verbose)
+	assignBoolIfUnchanged(cmd, "debug", &opts.Debug, fileOpts.Debug)
+
+	opts.Overrides = fileOpts.Overrides
+	opts.BestPracticesRules = fileOpts.BestPracticesRules
+}
+
+// assignStringIfUnchanged sets *dst to value, unless flagName was explicitly
+// passed on the command line.
+func assignStringIfUnchanged(cmd *cobra.Command, flagName string, dst *string, value string) {
+	if !cmd.Flags().Changed(flagName) {
+		*dst = value
+	}
+}
+
+// assignBoolIfUnchanged sets *dst to value, unless flagName was explicitly
+// passed on the command line.
+func assignBoolIfUnchanged(cmd *cobra.Command, flagName string, dst *bool, value bool) {
+	if !cmd.Flags().Changed(flagName) {
+		*dst = value
+	}
+}
+
+// assignIntIfUnchanged sets *dst to value, unless flagName was explicitly
+// passed on the command line.
+func assignIntIfUnchanged(cmd *cobra.Command, flagName string, dst *int, value int) {
+	if !cmd.Flags().Changed(flagName) {
+		*dst = value
+	}
+}
+
+// assignStringSliceIfUnchanged sets *dst to value, unless flagName was
+// explicitly passed on the command line.
+func assignStringSliceIfUnchanged(cmd *cobra.Command, flagName string, dst *[]string, value []string) {
+	if !cmd.Flags().Changed(flagName) {
+		*dst = value
+	}
+}
+
+// applySchemaOverrides merges each per-path schema fragment in overrides
+// into the matching node of schema, skipping paths the generated schema
+// doesn't have a property for.
+func applySchemaOverrides(ctx context.Context, schema *jsonschema.Schema, overrides map[string]SchemaOverride) {
+	logger := logging.WithComponent(ctx, "cli")
+
+	for path, override := range overrides {
+		node := findSchemaNode(schema, strings.Split(path, "."))
+		if node == nil {
+			logger.InfoContext(ctx, "override path not found in generated schema, skipping", "path", path)
+			continue
+		}
+		override.applyTo(node)
+	}
+}
+
+// findSchemaNode walks schema.Properties along path, returning nil if any
+// segment along the way doesn't exist.
+func findSchemaNode(schema *jsonschema.Schema, path []string) *jsonschema.Schema {
+	node := schema
+	for _, key := range path {
+		if node == nil || node.Properties == nil {
+			return nil
+		}
+		node = node.Properties[key]
+	}
+	return node
+}
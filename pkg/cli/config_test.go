@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "schema.yaml")
+
+	configYAML := `
+title: My Chart Schema
+requireByDefault: true
+overrides:
+  image.tag:
+    pattern: "^v\\d+"
+    description: The image tag to deploy
+`
+	if err := os.WriteFile(configPath, []byte(configYAML), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	opts, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if opts.Title != "My Chart Schema" {
+		t.Errorf("expected title from config, got %q", opts.Title)
+	}
+	if !opts.RequireByDefault {
+		t.Error("expected requireByDefault from config to be true")
+	}
+	if !opts.IncludeExamples {
+		t.Error("expected includeExamples to keep its default of true")
+	}
+
+	override, ok := opts.Overrides["image.tag"]
+	if !ok {
+		t.Fatalf("expected an override for image.tag")
+	}
+	if override.Pattern != "^v\\d+" {
+		t.Errorf("expected pattern override, got %q", override.Pattern)
+	}
+	if override.Description != "The image tag to deploy" {
+		t.Errorf("expected description override, got %q", override.Description)
+	}
+}
+
+func TestApplySchemaOverrides(t *testing.T) {
+	ctx := context.Background()
+
+	schema := &jsonschema.Schema{
+		Type: jsonschema.TypeObject,
+		Properties: map[string]*jsonschema.Schema{
+			"image": {
+				Type: jsonschema.TypeObject,
+				Properties: map[string]*jsonschema.Schema{
+					"tag": {Type: jsonschema.TypeString},
+				},
+			},
+		},
+	}
+
+	overrides := map[string]SchemaOverride{
+		"image.tag": {
+			Pattern:     "^v\\d+",
+			Description: "The image tag to deploy",
+		},
+		"missing.path": {
+			Description: "should be skipped",
+		},
+	}
+
+	applySchemaOverrides(ctx, schema, overrides)
+
+	tag := schema.Properties["image"].Properties["tag"]
+	if tag.Pattern != "^v\\d+" {
+		t.Errorf("expected pattern to be applied, got %q", tag.Pattern)
+	}
+	if tag.Description != "The image tag to deploy" {
+		t.Errorf("expected description to be applied, got %q", tag.Description)
+	}
+}
+
+func TestDiscoverConfigFileWalksUpward(t *testing.T) {
+	root := t.TempDir()
+	chartDir := filepath.Join(root, "charts", "api")
+	if err := os.MkdirAll(chartDir, 0750); err != nil {
+		t.Fatalf("failed to create chart dir: %v", err)
+	}
+
+	configPath := filepath.Join(root, ".helm-schema-gen.yaml")
+	if err := os.WriteFile(configPath, []byte("title: Repo-wide Schema\n"), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	found := discoverConfigFile(chartDir)
+	if found != configPath {
+		t.Errorf("expected discoverConfigFile to find %s by walking upward, got %q", configPath, found)
+	}
+}
+
+func TestMergeConfigIntoOptions_FlagsWinOverConfig(t *testing.T) {
+	rootCmd := NewRootCommand()
+
+	if err := rootCmd.Flags().Set("title", "CLI Title"); err != nil {
+		t.Fatalf("failed to set title flag: %v", err)
+	}
+	if err := rootCmd.Flags().Parse([]string{"--title", "CLI Title"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+
+	opts := &Options{Title: "CLI Title", OutputFile: "values.schema.json"}
+	fileOpts := &Options{Title: "Config Title", OutputFile: "config.schema.json"}
+
+	mergeConfigIntoOptions(rootCmd, opts, fileOpts)
+
+	if opts.Title != "CLI Title" {
+		t.Errorf("expected explicitly-set flag to win, got title %q", opts.Title)
+	}
+	if opts.OutputFile != "config.schema.json" {
+		t.Errorf("expected config value for an unset flag, got output %q", opts.OutputFile)
+	}
+}
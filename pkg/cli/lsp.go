@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dc-tec/helm-schema-gen/pkg/lsp"
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+	"github.com/spf13/cobra"
+)
+
+// newLSPCommand creates the "lsp" subcommand, which serves a Language
+// Server Protocol server over stdio for editors to attach to while editing
+// values.yaml. See pkg/lsp for the protocol surface it implements.
+func newLSPCommand() *cobra.Command {
+	opts := DefaultOptions()
+
+	lspCmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Start a Language Server Protocol server for values.yaml",
+		Long:  `lsp serves an LSP server over stdio, publishing diagnostics, hover, and completion for values.yaml files backed by the schema generator and Helm best-practices validator. Point an editor's LSP client (e.g. VS Code or Neovim) at "helm-schema-gen lsp".`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if err := applyConfigFile(cmd, opts); err != nil {
+				return err
+			}
+			return runLSPCommand(ctx, opts)
+		},
+	}
+
+	lspCmd.Flags().StringVar(&opts.ConfigFile, "config", opts.ConfigFile, "Path to a YAML config file providing defaults for these flags")
+	lspCmd.Flags().StringVar(&opts.SchemaVersion, "schema-version", opts.SchemaVersion, "JSON Schema version to use")
+	lspCmd.Flags().StringVar(&opts.Draft, "draft", opts.Draft, "Shorthand JSON Schema draft to use (4, 6, 7, 2019, 2020); overrides --schema-version when set")
+	lspCmd.Flags().StringVar(&opts.RulesFile, "rules", opts.RulesFile, "Path to a YAML/JSON file of custom pattern rules")
+	lspCmd.Flags().BoolVar(&opts.ReplaceRules, "replace-rules", opts.ReplaceRules, "Make --rules the complete set of pattern rules rather than merging it ahead of the built-in table")
+	lspCmd.Flags().BoolVar(&opts.ExtractDescriptions, "extract-descriptions", opts.ExtractDescriptions, "Extract descriptions from comments")
+	lspCmd.Flags().BoolVar(&opts.InferTuples, "infer-tuples", opts.InferTuples, "Infer positional (tuple) schemas for fixed-shape heterogeneous arrays")
+	lspCmd.Flags().BoolVar(&opts.SpecializeForHelm, "specialize-helm", opts.SpecializeForHelm, "Replace recognized Helm config blocks with hand-tuned schemas")
+	lspCmd.Flags().StringSliceVar(&opts.DisabledRecognizers, "disable-recognizers", opts.DisabledRecognizers, "Comma-separated recognizer names to skip when --specialize-helm is set, e.g. image,resources")
+	lspCmd.Flags().BoolVar(&opts.Debug, "debug", opts.Debug, "Enable debug output")
+
+	return lspCmd
+}
+
+// runLSPCommand builds a jsonschema.Generator from opts and serves it over
+// stdio until the client sends "exit" or stdin closes. Unlike the generate
+// and watch commands, it has no InputFile/OutputFile of its own: the editor
+// supplies document text over didOpen/didChange instead.
+func runLSPCommand(ctx context.Context, opts *Options) error {
+	schemaVersion := jsonschema.SchemaVersion(opts.SchemaVersion)
+	if opts.Draft != "" {
+		version, err := jsonschema.ParseDraftShorthand(opts.Draft)
+		if err != nil {
+			return fmt.Errorf("invalid --draft: %w", err)
+		}
+		schemaVersion = version
+	}
+
+	genOpts := jsonschema.GeneratorOptions{
+		SchemaVersion:       schemaVersion,
+		Title:               opts.Title,
+		Description:         opts.Description,
+		ExtractDescriptions: opts.ExtractDescriptions,
+		ReplacePatternRules: opts.ReplaceRules,
+		InferTuples:         opts.InferTuples,
+		SpecializeForHelm:   opts.SpecializeForHelm,
+		DisabledRecognizers: opts.DisabledRecognizers,
+		Debug:               opts.Debug,
+	}
+
+	server, err := lsp.NewServer(genOpts, opts.RulesFile)
+	if err != nil {
+		return fmt.Errorf("failed to start LSP server: %w", err)
+	}
+	return server.Run(ctx)
+}
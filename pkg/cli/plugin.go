@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dc-tec/helm-schema-gen/pkg/logging"
+	"github.com/dc-tec/helm-schema-gen/pkg/plugin"
+	"github.com/spf13/cobra"
+)
+
+// newPluginCommand creates the "plugin" subcommand group, which manages
+// plugins installed under a plugins directory (see resolvePluginsDir).
+func newPluginCommand() *cobra.Command {
+	var pluginsDir string
+
+	pluginCmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage helm-schema-gen plugins",
+		Long:  `plugin lists, installs, and removes plugins that run against the generated schema (see --plugins-dir on the root command, and $HELM_SCHEMA_GEN_PLUGINS).`,
+	}
+	pluginCmd.PersistentFlags().StringVar(&pluginsDir, "plugins-dir", "", "Plugins directory to operate on (defaults to $HELM_SCHEMA_GEN_PLUGINS)")
+
+	pluginCmd.AddCommand(newPluginListCommand(&pluginsDir))
+	pluginCmd.AddCommand(newPluginInstallCommand(&pluginsDir))
+	pluginCmd.AddCommand(newPluginRemoveCommand(&pluginsDir))
+
+	return pluginCmd
+}
+
+// resolvePluginsDirFlag returns *pluginsDir, falling back to
+// $HELM_SCHEMA_GEN_PLUGINS, returning an error if neither is set.
+func resolvePluginsDirFlag(pluginsDir *string) (string, error) {
+	if *pluginsDir != "" {
+		return *pluginsDir, nil
+	}
+	if dir := os.Getenv("HELM_SCHEMA_GEN_PLUGINS"); dir != "" {
+		return dir, nil
+	}
+	return "", fmt.Errorf("no plugins directory configured: pass --plugins-dir or set $HELM_SCHEMA_GEN_PLUGINS")
+}
+
+// newPluginListCommand creates the "plugin list" subcommand.
+func newPluginListCommand(pluginsDir *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List installed plugins",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := resolvePluginsDirFlag(pluginsDir)
+			if err != nil {
+				return err
+			}
+
+			plugins, err := plugin.FindPlugins(dir)
+			if err != nil {
+				return fmt.Errorf("failed to list plugins: %w", err)
+			}
+			if len(plugins) == 0 {
+				fmt.Printf("no plugins installed in %s\n", dir)
+				return nil
+			}
+
+			for _, p := range plugins {
+				fmt.Printf("%s\t%s\t%s\n", p.Name, p.Version, p.Description)
+			}
+			return nil
+		},
+	}
+}
+
+// newPluginInstallCommand creates the "plugin install" subcommand.
+func newPluginInstallCommand(pluginsDir *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "install <path>",
+		Short: "Install a plugin from a local directory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			logger := logging.WithComponent(ctx, "cli")
+
+			dir, err := resolvePluginsDirFlag(pluginsDir)
+			if err != nil {
+				return err
+			}
+
+			installed, err := plugin.Install(args[0], dir)
+			if err != nil {
+				return fmt.Errorf("failed to install plugin: %w", err)
+			}
+
+			logger.InfoContext(ctx, "plugin installed", "name", installed.Name, "version", installed.Version, "dir", installed.Dir)
+			fmt.Printf("installed %s %s to %s\n", installed.Name, installed.Version, installed.Dir)
+			return nil
+		},
+	}
+}
+
+// newPluginRemoveCommand creates the "plugin remove" subcommand.
+func newPluginRemoveCommand(pluginsDir *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove an installed plugin",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := resolvePluginsDirFlag(pluginsDir)
+			if err != nil {
+				return err
+			}
+
+			if err := plugin.Remove(dir, args[0]); err != nil {
+				return fmt.Errorf("failed to remove plugin: %w", err)
+			}
+
+			fmt.Printf("removed %s\n", args[0])
+			return nil
+		},
+	}
+}
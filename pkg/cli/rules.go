@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+	"github.com/spf13/cobra"
+)
+
+// RulesConfig configures the Helm best-practices RuleSet (see
+// jsonschema.RuleSet) from a config file's "bestPracticesRules" key,
+// layered on top of (and merged with) the --disable-rule/--enable-only
+// CLI flags.
+type RulesConfig struct {
+	// Disable lists rule IDs to turn off.
+	Disable []string `yaml:"disable,omitempty"`
+	// EnableOnly, if non-empty, disables every rule not listed.
+	EnableOnly []string `yaml:"enableOnly,omitempty"`
+	// Severity overrides a rule's default level, e.g.
+	// {"naming/camelcase": "error"}.
+	Severity map[string]string `yaml:"severity,omitempty"`
+	// Thresholds overrides a ThresholdRule's integer threshold, e.g.
+	// {"structure/nesting-depth": 8}.
+	Thresholds map[string]int `yaml:"thresholds,omitempty"`
+	// Custom defines additional regex-based property-name rules.
+	Custom []CustomRuleConfig `yaml:"custom,omitempty"`
+}
+
+// CustomRuleConfig defines one inline regex-based rule: every object
+// property name Pattern matches is reported at Level with Message.
+type CustomRuleConfig struct {
+	ID      string `yaml:"id"`
+	Pattern string `yaml:"pattern"`
+	Message string `yaml:"message"`
+	Level   string `yaml:"level"`
+}
+
+// buildRuleSet assembles the best-practices RuleSet used for a
+// generation run: jsonschema.DefaultRuleSet, plus opts.BestPracticesRules'
+// custom rules, disables, severity overrides, and thresholds, plus the
+// opts.DisableRules/opts.EnableOnlyRules CLI flags layered on top.
+func buildRuleSet(opts *Options) (*jsonschema.RuleSet, error) {
+	cfg := opts.BestPracticesRules
+	ruleSet := jsonschema.DefaultRuleSet()
+
+	for _, custom := range cfg.Custom {
+		rule, err := jsonschema.NewRegexPropertyRule(custom.ID, custom.Pattern, custom.Message, jsonschema.ValidationLevel(custom.Level))
+		if err != nil {
+			return nil, err
+		}
+		ruleSet.Add(rule)
+	}
+
+	for _, id := range cfg.Disable {
+		ruleSet.Disable(id)
+	}
+	if len(cfg.EnableOnly) > 0 {
+		ruleSet.EnableOnly(cfg.EnableOnly)
+	}
+	for id, level := range cfg.Severity {
+		ruleSet.SetSeverity(id, jsonschema.ValidationLevel(level))
+	}
+	for id, threshold := range cfg.Thresholds {
+		ruleSet.SetThreshold(id, threshold)
+	}
+
+	for _, id := range opts.DisableRules {
+		ruleSet.Disable(id)
+	}
+	if len(opts.EnableOnlyRules) > 0 {
+		ruleSet.EnableOnly(opts.EnableOnlyRules)
+	}
+
+	return ruleSet, nil
+}
+
+// newRulesCommand creates the "rules" command and its "list" subcommand,
+// which prints every registered best-practices rule with its default
+// severity and description.
+func newRulesCommand() *cobra.Command {
+	rulesCmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Inspect the Helm best-practices rule set",
+	}
+	rulesCmd.AddCommand(newRulesListCommand())
+	return rulesCmd
+}
+
+// newRulesListCommand creates the "rules list" subcommand.
+func newRulesListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List every registered best-practices rule and its default severity",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRulesListCommand(cmd)
+		},
+	}
+}
+
+// runRulesListCommand prints every rule in jsonschema.DefaultRuleSet as an
+// aligned table of ID, default severity, and description.
+func runRulesListCommand(cmd *cobra.Command) error {
+	rules := jsonschema.DefaultRuleSet().Rules()
+
+	type row struct {
+		id, level, description string
+	}
+	rows := make([]row, 0, len(rules))
+	for _, rule := range rules {
+		described, ok := rule.(jsonschema.DescribedRule)
+		if !ok {
+			rows = append(rows, row{id: rule.ID()})
+			continue
+		}
+		rows = append(rows, row{id: described.ID(), level: string(described.DefaultLevel()), description: described.Description()})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].id < rows[j].id })
+
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tDEFAULT SEVERITY\tDESCRIPTION")
+	for _, r := range rows {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.id, r.level, r.description)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+func TestBuildRuleSet(t *testing.T) {
+	opts := DefaultOptions()
+	opts.BestPracticesRules = RulesConfig{
+		Disable:  []string{"docs/examples"},
+		Severity: map[string]string{"docs/description": "error"},
+		Custom: []CustomRuleConfig{
+			{ID: "custom/no-todo", Pattern: "(?i)todo", Message: "avoid TODO in property names", Level: "warning"},
+		},
+	}
+	opts.DisableRules = []string{"naming/camelcase"}
+
+	ruleSet, err := buildRuleSet(opts)
+	if err != nil {
+		t.Fatalf("buildRuleSet returned an error: %v", err)
+	}
+
+	schema := &jsonschema.Schema{
+		Type: jsonschema.TypeObject,
+		Properties: map[string]*jsonschema.Schema{
+			"todoList": {Type: jsonschema.TypeArray, Description: "x"},
+		},
+	}
+
+	issues := ruleSet.Validate(schema)
+
+	var sawExamples, sawCustom bool
+	for _, issue := range issues {
+		switch issue.Message {
+		case "Consider adding examples or default value":
+			sawExamples = true
+		case "avoid TODO in property names":
+			sawCustom = true
+		}
+	}
+	if sawExamples {
+		t.Error("expected docs/examples to be disabled")
+	}
+	if !sawCustom {
+		t.Error("expected the custom regex rule to fire on todoList")
+	}
+}
+
+func TestBuildRuleSetInvalidCustomPattern(t *testing.T) {
+	opts := DefaultOptions()
+	opts.BestPracticesRules = RulesConfig{
+		Custom: []CustomRuleConfig{{ID: "bad", Pattern: "(", Message: "broken", Level: "warning"}},
+	}
+
+	if _, err := buildRuleSet(opts); err == nil {
+		t.Fatal("expected an error for an invalid custom rule regex")
+	}
+}
+
+func TestRunRulesListCommand(t *testing.T) {
+	cmd := newRulesListCommand()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+
+	if err := runRulesListCommand(cmd); err != nil {
+		t.Fatalf("runRulesListCommand returned an error: %v", err)
+	}
+
+	out := buf.String()
+	if !bytes.Contains(buf.Bytes(), []byte("naming/camelcase")) {
+		t.Errorf("expected the rule list to include naming/camelcase, got:\n%s", out)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("ID")) {
+		t.Errorf("expected a header row, got:\n%s", out)
+	}
+}
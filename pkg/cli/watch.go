@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dc-tec/helm-schema-gen/pkg/logging"
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+)
+
+// watchDebounce is how long watch waits after the last filesystem event
+// before regenerating, so a burst of writes from an editor or a `helm`
+// template render collapses into a single run.
+const watchDebounce = 200 * time.Millisecond
+
+// newWatchCommand creates the "watch" subcommand, which regenerates the
+// schema every time opts.InputFile changes on disk.
+func newWatchCommand() *cobra.Command {
+	opts := DefaultOptions()
+	var onChange string
+
+	watchCmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Regenerate the schema whenever values.yaml changes",
+		Long:  `watch observes the input values.yaml file and rewrites its schema on every change, printing a concise diff of added/removed/changed schema paths and optionally invoking an external validator after each write (--on-change).`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if err := applyConfigFile(cmd, opts); err != nil {
+				return err
+			}
+			return runWatchCommand(ctx, opts, onChange)
+		},
+	}
+
+	watchCmd.Flags().StringVarP(&opts.InputFile, "file", "f", opts.InputFile, "Input values.yaml file to watch")
+	watchCmd.Flags().StringVarP(&opts.OutputFile, "output", "o", opts.OutputFile, "Output schema file")
+	watchCmd.Flags().StringVar(&opts.ConfigFile, "config", opts.ConfigFile, "Path to a YAML config file providing defaults for these flags")
+	watchCmd.Flags().StringVar(&opts.SchemaVersion, "schema-version", opts.SchemaVersion, "JSON Schema version to use")
+	watchCmd.Flags().StringVar(&opts.Draft, "draft", opts.Draft, "Shorthand JSON Schema draft to use (4, 6, 7, 2019, 2020); overrides --schema-version when set")
+	watchCmd.Flags().BoolVar(&opts.ExtractDescriptions, "extract-descriptions", opts.ExtractDescriptions, "Extract descriptions from comments")
+	watchCmd.Flags().BoolVar(&opts.ValidateBestPractices, "validate", opts.ValidateBestPractices, "Validate schema against Helm best practices on every regeneration")
+	watchCmd.Flags().BoolVar(&opts.SpecializeForHelm, "specialize-helm", opts.SpecializeForHelm, "Replace recognized Helm config blocks with hand-tuned schemas")
+	watchCmd.Flags().BoolVar(&opts.NoCache, "no-cache", opts.NoCache, "Disable the on-disk schema cache")
+	watchCmd.Flags().StringVar(&opts.PluginsDir, "plugins-dir", opts.PluginsDir, "Directory of plugins to run against the generated schema")
+	watchCmd.Flags().StringVar(&onChange, "on-change", "", `Shell command to run after each successful regeneration, e.g. "kubeval values.schema.json"`)
+	watchCmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", opts.Verbose, "Enable verbose output")
+
+	return watchCmd
+}
+
+// runWatchCommand regenerates the schema once up front, then watches
+// opts.InputFile for changes, debouncing rapid edits, regenerating and
+// diffing against the previous schema on each one, and running onChange (if
+// set) after every successful write. It blocks until ctx is canceled or the
+// process receives SIGINT/SIGTERM.
+func runWatchCommand(ctx context.Context, opts *Options, onChange string) error {
+	logger := logging.WithComponent(ctx, "cli")
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start filesystem watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// fsnotify watches directories, not individual files (editors commonly
+	// replace a file via rename-into-place, which a file-level watch would
+	// miss), so watch the input file's parent and filter events to it.
+	watchDir := filepath.Dir(opts.InputFile)
+	if !filepath.IsAbs(watchDir) {
+		if cwd, err := os.Getwd(); err == nil {
+			watchDir = filepath.Join(cwd, watchDir)
+		}
+	}
+	if err := watcher.Add(watchDir); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", watchDir, err)
+	}
+
+	logger.InfoContext(ctx, "watching for changes", "file", opts.InputFile)
+
+	previous, err := generateAndWriteSchema(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("initial schema generation failed: %w", err)
+	}
+	runOnChange(ctx, logger, onChange)
+
+	var debounce *time.Timer
+	defer func() {
+		if debounce != nil {
+			debounce.Stop()
+		}
+	}()
+	pending := make(chan struct{}, 1)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "watch stopped")
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(filepath.Join(watchDir, filepath.Base(opts.InputFile))) {
+				continue
+			}
+			if debounce == nil {
+				debounce = time.AfterFunc(watchDebounce, func() {
+					select {
+					case pending <- struct{}{}:
+					default:
+					}
+				})
+			} else {
+				debounce.Reset(watchDebounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.WarnContext(ctx, "filesystem watcher error", "error", err)
+
+		case <-pending:
+			schema, err := generateAndWriteSchema(ctx, opts)
+			if err != nil {
+				logger.ErrorContext(ctx, "regeneration failed", "error", err)
+				continue
+			}
+
+			added, removed, changed := jsonschema.DiffSchemaPaths(previous, schema)
+			logger.InfoContext(ctx, "schema regenerated",
+				"added", strings.Join(added, ", "),
+				"removed", strings.Join(removed, ", "),
+				"changed", strings.Join(changed, ", "),
+			)
+			previous = schema
+
+			runOnChange(ctx, logger, onChange)
+		}
+	}
+}
+
+// runOnChange runs onChange as a shell command, if non-empty, logging its
+// outcome rather than failing the watch loop over it.
+func runOnChange(ctx context.Context, logger *slog.Logger, onChange string) {
+	if onChange == "" {
+		return
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", onChange) // #nosec G204 -- onChange is an operator-supplied --on-change flag, the same trust level as any other CLI flag
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		logger.WarnContext(ctx, "on-change command failed", "command", onChange, "error", err, "output", string(output))
+		return
+	}
+	logger.InfoContext(ctx, "on-change command succeeded", "command", onChange)
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunWatchCommand_RegeneratesOnChange(t *testing.T) {
+	dir := t.TempDir()
+	inputPath := filepath.Join(dir, "values.yaml")
+	outputPath := filepath.Join(dir, "values.schema.json")
+
+	if err := os.WriteFile(inputPath, []byte("replicaCount: 1\n"), 0600); err != nil {
+		t.Fatalf("failed to write input file: %v", err)
+	}
+
+	opts := DefaultOptions()
+	opts.InputFile = inputPath
+	opts.OutputFile = outputPath
+	opts.NoCache = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- runWatchCommand(ctx, opts, "")
+	}()
+
+	// Give the watcher a moment to start, then change the watched file.
+	time.Sleep(150 * time.Millisecond)
+	if err := os.WriteFile(inputPath, []byte("replicaCount: 1\nimage:\n  repository: nginx\n"), 0600); err != nil {
+		t.Fatalf("failed to update input file: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("runWatchCommand returned an error: %v", err)
+		}
+	case <-time.After(4 * time.Second):
+		t.Fatal("runWatchCommand did not return after context cancellation")
+	}
+
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output schema: %v", err)
+	}
+	if !strings.Contains(string(data), "image") {
+		t.Errorf("expected the regenerated schema to include the new 'image' property, got %s", data)
+	}
+}
@@ -0,0 +1,311 @@
+// Package examplegen renders a JSON Schema (as produced by
+// pkg/schema-generator) back into an example Helm values.yaml document,
+// closing the loop with jsonschema.Generator.GenerateFromYAML.
+package examplegen
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+// Options controls how Generate renders a schema's example values.yaml.
+type Options struct {
+	// OnlyRequired skips properties not listed in a schema's Required, at
+	// every nesting level.
+	OnlyRequired bool
+
+	// IncludeComments emits each property's Description as a "# "-prefixed
+	// comment on the line above it.
+	IncludeComments bool
+
+	// MaxDepth caps how many levels of nested objects/arrays Generate
+	// descends into before omitting their children (emitting an empty
+	// "{}"/"[]" instead). Zero means unlimited.
+	MaxDepth int
+}
+
+// Generate walks schema and renders an example Helm values.yaml document.
+func Generate(schema *jsonschema.Schema, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	if schema == nil || len(schema.Properties) == 0 {
+		return buf.Bytes(), nil
+	}
+
+	defs := schemaDefs(schema)
+	if err := writeProperties(&buf, schema, defs, opts, 0, 0, map[string]bool{}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// schemaDefs collects schema's $defs and definitions buckets into one map,
+// keyed by def name, for resolving $ref properties.
+func schemaDefs(schema *jsonschema.Schema) map[string]*jsonschema.Schema {
+	defs := make(map[string]*jsonschema.Schema, len(schema.Defs)+len(schema.Definitions))
+	for name, def := range schema.Defs {
+		defs[name] = def
+	}
+	for name, def := range schema.Definitions {
+		defs[name] = def
+	}
+	return defs
+}
+
+// defNameFromRef extracts the def name from a "#/$defs/Name" or
+// "#/definitions/Name" JSON Pointer.
+func defNameFromRef(ref string) string {
+	for i := len(ref) - 1; i >= 0; i-- {
+		if ref[i] == '/' {
+			return ref[i+1:]
+		}
+	}
+	return ref
+}
+
+// derefSchema resolves schema's $ref (if any) against defs, returning
+// schema unchanged if it isn't a reference.
+func derefSchema(schema *jsonschema.Schema, defs map[string]*jsonschema.Schema) *jsonschema.Schema {
+	if schema == nil || schema.Ref == "" {
+		return schema
+	}
+	return defs[defNameFromRef(schema.Ref)]
+}
+
+// writeProperties writes schema's Properties, sorted by key, at the given
+// indent level (two spaces per level) and nesting depth.
+func writeProperties(buf *bytes.Buffer, schema *jsonschema.Schema, defs map[string]*jsonschema.Schema, opts Options, indent, depth int, visited map[string]bool) error {
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		if opts.OnlyRequired && !required[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := writeProperty(buf, name, schema.Properties[name], defs, opts, indent, depth, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeProperty renders a single "name: value" entry (and its Description
+// comment, if requested), recursing into nested objects and arrays.
+func writeProperty(buf *bytes.Buffer, name string, prop *jsonschema.Schema, defs map[string]*jsonschema.Schema, opts Options, indent, depth int, visited map[string]bool) error {
+	if prop != nil && prop.Ref != "" {
+		refName := defNameFromRef(prop.Ref)
+		if visited[refName] {
+			return nil
+		}
+		visited = cloneVisited(visited)
+		visited[refName] = true
+	}
+	prop = derefSchema(prop, defs)
+	if prop == nil {
+		return nil
+	}
+
+	prefix := strings.Repeat("  ", indent)
+
+	if opts.IncludeComments && prop.Description != "" {
+		fmt.Fprintf(buf, "%s# %s\n", prefix, prop.Description)
+	}
+
+	kind := primaryType(prop)
+
+	switch {
+	case kind == jsonschema.TypeObject && len(prop.Properties) > 0:
+		if opts.MaxDepth > 0 && depth+1 >= opts.MaxDepth {
+			fmt.Fprintf(buf, "%s%s: {}\n", prefix, name)
+			return nil
+		}
+		fmt.Fprintf(buf, "%s%s:\n", prefix, name)
+		return writeProperties(buf, prop, defs, opts, indent+1, depth+1, visited)
+
+	case kind == jsonschema.TypeArray:
+		if opts.MaxDepth > 0 && depth+1 >= opts.MaxDepth {
+			fmt.Fprintf(buf, "%s%s: []\n", prefix, name)
+			return nil
+		}
+		return writeArrayProperty(buf, name, prop, defs, opts, indent, depth, visited)
+
+	default:
+		fmt.Fprintf(buf, "%s%s: %s\n", prefix, name, formatScalar(exampleScalar(prop, kind)))
+		return nil
+	}
+}
+
+// writeArrayProperty emits a single example element derived from prop.Items.
+func writeArrayProperty(buf *bytes.Buffer, name string, prop *jsonschema.Schema, defs map[string]*jsonschema.Schema, opts Options, indent, depth int, visited map[string]bool) error {
+	prefix := strings.Repeat("  ", indent)
+
+	items := prop.Items
+	if items != nil && items.Ref != "" {
+		refName := defNameFromRef(items.Ref)
+		if visited[refName] {
+			fmt.Fprintf(buf, "%s%s: []\n", prefix, name)
+			return nil
+		}
+		visited = cloneVisited(visited)
+		visited[refName] = true
+	}
+	item := derefSchema(items, defs)
+	if item == nil {
+		fmt.Fprintf(buf, "%s%s: []\n", prefix, name)
+		return nil
+	}
+
+	itemKind := primaryType(item)
+
+	if itemKind != jsonschema.TypeObject || len(item.Properties) == 0 {
+		fmt.Fprintf(buf, "%s%s:\n", prefix, name)
+		fmt.Fprintf(buf, "%s  - %s\n", prefix, formatScalar(exampleScalar(item, itemKind)))
+		return nil
+	}
+
+	var itemBuf bytes.Buffer
+	if err := writeProperties(&itemBuf, item, defs, opts, 0, depth+1, visited); err != nil {
+		return err
+	}
+	rendered := strings.TrimRight(itemBuf.String(), "\n")
+	if rendered == "" {
+		fmt.Fprintf(buf, "%s%s: []\n", prefix, name)
+		return nil
+	}
+
+	lines := strings.Split(rendered, "\n")
+	fmt.Fprintf(buf, "%s%s:\n", prefix, name)
+	fmt.Fprintf(buf, "%s  - %s\n", prefix, lines[0])
+	for _, line := range lines[1:] {
+		fmt.Fprintf(buf, "%s    %s\n", prefix, line)
+	}
+	return nil
+}
+
+// cloneVisited copies a $ref-name visited set so sibling branches (e.g. an
+// array's Items alongside its own properties) don't spuriously trip each
+// other's cycle guard.
+func cloneVisited(visited map[string]bool) map[string]bool {
+	clone := make(map[string]bool, len(visited))
+	for name, v := range visited {
+		clone[name] = v
+	}
+	return clone
+}
+
+// primaryType returns the first non-null type for prop, treating a
+// multi-type union as if it were its first non-null candidate (or its
+// first entry when every candidate is null) and defaulting to TypeString
+// when Type is unset or an empty list. Besides the
+// SchemaType/[]SchemaType a Generator produces in-process, it also accepts
+// the plain string/[]any shapes encoding/json leaves Type in after decoding
+// a schema file from disk (mirroring isTypeInArray's same []any handling).
+func primaryType(prop *jsonschema.Schema) jsonschema.SchemaType {
+	switch t := prop.Type.(type) {
+	case jsonschema.SchemaType:
+		return t
+	case string:
+		return jsonschema.SchemaType(t)
+	case []jsonschema.SchemaType:
+		for _, candidate := range t {
+			if candidate != jsonschema.TypeNull {
+				return candidate
+			}
+		}
+		if len(t) > 0 {
+			return t[0]
+		}
+	case []any:
+		var first jsonschema.SchemaType
+		for i, candidate := range t {
+			str, ok := candidate.(string)
+			if !ok {
+				continue
+			}
+			if i == 0 {
+				first = jsonschema.SchemaType(str)
+			}
+			if jsonschema.SchemaType(str) != jsonschema.TypeNull {
+				return jsonschema.SchemaType(str)
+			}
+		}
+		if first != "" {
+			return first
+		}
+	}
+	return jsonschema.TypeString
+}
+
+// exampleScalar picks an example value for prop in priority order: its
+// Default, the first of its Examples, the first of its Enum, a value
+// synthesized from its Format, or finally the zero value for kind.
+func exampleScalar(prop *jsonschema.Schema, kind jsonschema.SchemaType) any {
+	if prop.Default != nil {
+		return prop.Default
+	}
+	if len(prop.Examples) > 0 {
+		return prop.Examples[0]
+	}
+	if len(prop.Enum) > 0 {
+		return prop.Enum[0]
+	}
+
+	switch prop.Format {
+	case "date":
+		return "2024-01-01"
+	case "date-time":
+		return "2024-01-01T00:00:00Z"
+	case "email":
+		return "user@example.com"
+	case "uri":
+		return "https://example.com"
+	}
+
+	switch kind {
+	case jsonschema.TypeBoolean:
+		return false
+	case jsonschema.TypeInteger:
+		return 0
+	case jsonschema.TypeNumber:
+		return 0.0
+	case jsonschema.TypeNull:
+		return nil
+	default:
+		return ""
+	}
+}
+
+// formatScalar renders value as a YAML scalar literal. Strings are always
+// double-quoted so a synthesized or templated value can't be accidentally
+// reinterpreted as a different YAML type.
+func formatScalar(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return `"` + strings.ReplaceAll(v, `"`, `\"`) + `"`
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return `"` + strings.ReplaceAll(fmt.Sprintf("%v", v), `"`, `\"`) + `"`
+	}
+}
@@ -0,0 +1,220 @@
+package examplegen
+
+import (
+	"strings"
+	"testing"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+func TestGenerate_ScalarsAndDefaults(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type: jsonschema.TypeObject,
+		Properties: map[string]*jsonschema.Schema{
+			"replicaCount": {Type: jsonschema.TypeInteger, Default: 3},
+			"image": {
+				Type: jsonschema.TypeObject,
+				Properties: map[string]*jsonschema.Schema{
+					"repository": {Type: jsonschema.TypeString, Examples: []any{"nginx"}},
+					"tag":        {Type: jsonschema.TypeString},
+				},
+			},
+		},
+	}
+
+	out, err := Generate(schema, Options{})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	got := string(out)
+	want := "image:\n  repository: \"nginx\"\n  tag: \"\"\nreplicaCount: 3\n"
+	if got != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGenerate_Comments(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type: jsonschema.TypeObject,
+		Properties: map[string]*jsonschema.Schema{
+			"debug": {Type: jsonschema.TypeBoolean, Description: "Enable verbose debug logging"},
+		},
+	}
+
+	out, err := Generate(schema, Options{IncludeComments: true})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	want := "# Enable verbose debug logging\ndebug: false\n"
+	if string(out) != want {
+		t.Errorf("unexpected output: %q, want %q", out, want)
+	}
+}
+
+func TestGenerate_OnlyRequired(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type:     jsonschema.TypeObject,
+		Required: []string{"name"},
+		Properties: map[string]*jsonschema.Schema{
+			"name":    {Type: jsonschema.TypeString, Examples: []any{"my-app"}},
+			"comment": {Type: jsonschema.TypeString},
+		},
+	}
+
+	out, err := Generate(schema, Options{OnlyRequired: true})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if strings.Contains(string(out), "comment") {
+		t.Errorf("expected non-required property to be skipped, got %q", out)
+	}
+	if !strings.Contains(string(out), `name: "my-app"`) {
+		t.Errorf("expected the required property to be rendered, got %q", out)
+	}
+}
+
+func TestGenerate_ArrayOfScalars(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type: jsonschema.TypeObject,
+		Properties: map[string]*jsonschema.Schema{
+			"ports": {
+				Type:  jsonschema.TypeArray,
+				Items: &jsonschema.Schema{Type: jsonschema.TypeInteger, Default: 80},
+			},
+		},
+	}
+
+	out, err := Generate(schema, Options{})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	want := "ports:\n  - 80\n"
+	if string(out) != want {
+		t.Errorf("unexpected output: %q, want %q", out, want)
+	}
+}
+
+func TestGenerate_ArrayOfObjects(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type: jsonschema.TypeObject,
+		Properties: map[string]*jsonschema.Schema{
+			"volumes": {
+				Type: jsonschema.TypeArray,
+				Items: &jsonschema.Schema{
+					Type: jsonschema.TypeObject,
+					Properties: map[string]*jsonschema.Schema{
+						"name": {Type: jsonschema.TypeString, Examples: []any{"data"}},
+						"path": {Type: jsonschema.TypeString, Examples: []any{"/data"}},
+					},
+				},
+			},
+		},
+	}
+
+	out, err := Generate(schema, Options{})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	want := "volumes:\n  - name: \"data\"\n    path: \"/data\"\n"
+	if string(out) != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestGenerate_MultiTypePrefersFirstNonNull(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type: jsonschema.TypeObject,
+		Properties: map[string]*jsonschema.Schema{
+			"replicas": {Type: []jsonschema.SchemaType{jsonschema.TypeNull, jsonschema.TypeInteger}},
+		},
+	}
+
+	out, err := Generate(schema, Options{})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if string(out) != "replicas: 0\n" {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestGenerate_FormatSynthesis(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type: jsonschema.TypeObject,
+		Properties: map[string]*jsonschema.Schema{
+			"contactEmail": {Type: jsonschema.TypeString, Format: "email"},
+		},
+	}
+
+	out, err := Generate(schema, Options{})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if string(out) != "contactEmail: \"user@example.com\"\n" {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestGenerate_MaxDepthOmitsDeeperChildren(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type: jsonschema.TypeObject,
+		Properties: map[string]*jsonschema.Schema{
+			"outer": {
+				Type: jsonschema.TypeObject,
+				Properties: map[string]*jsonschema.Schema{
+					"inner": {Type: jsonschema.TypeString},
+				},
+			},
+		},
+	}
+
+	out, err := Generate(schema, Options{MaxDepth: 1})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if string(out) != "outer: {}\n" {
+		t.Errorf("expected children beyond MaxDepth to collapse to {}, got %q", out)
+	}
+}
+
+func TestGenerate_ResolvesRef(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type: jsonschema.TypeObject,
+		Defs: map[string]*jsonschema.Schema{
+			"Port": {Type: jsonschema.TypeObject, Properties: map[string]*jsonschema.Schema{
+				"name": {Type: jsonschema.TypeString, Examples: []any{"http"}},
+			}},
+		},
+		Properties: map[string]*jsonschema.Schema{
+			"service": {Ref: "#/$defs/Port"},
+		},
+	}
+
+	out, err := Generate(schema, Options{})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	want := "service:\n  name: \"http\"\n"
+	if string(out) != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestGenerate_EmptySchema(t *testing.T) {
+	out, err := Generate(&jsonschema.Schema{Type: jsonschema.TypeObject}, Options{})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(out) != 0 {
+		t.Errorf("expected empty output for a schema with no properties, got %q", out)
+	}
+}
@@ -0,0 +1,131 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ANSI color codes used by the console handler to highlight log levels.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiGray   = "\x1b[90m"
+	ansiBlue   = "\x1b[34m"
+	ansiYellow = "\x1b[33m"
+	ansiRed    = "\x1b[31m"
+)
+
+// consoleHandler is a slog.Handler tailored for interactive terminals: a
+// short, colorized "LEVEL message key=value ..." line rather than the
+// structured text/json handlers' machine-oriented output.
+type consoleHandler struct {
+	writer io.Writer
+	opts   *slog.HandlerOptions
+	attrs  []slog.Attr
+
+	// groupPrefix is the dot-joined, trailing-dot-terminated chain of
+	// WithGroup names in effect (e.g. "db." or "db.pool."), or "" outside
+	// any group. It's applied to every attr's key - both ones captured by
+	// WithAttrs and ones attached directly to the record - the same way
+	// slog.TextHandler qualifies grouped keys, so a grouped logger doesn't
+	// silently lose its group qualification in console output.
+	groupPrefix string
+}
+
+func newConsoleHandler(w io.Writer, opts *slog.HandlerOptions) *consoleHandler {
+	if opts == nil {
+		opts = &slog.HandlerOptions{}
+	}
+	return &consoleHandler{writer: w, opts: opts}
+}
+
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+func (h *consoleHandler) Handle(_ context.Context, r slog.Record) error {
+	levelColor := levelColor(r.Level)
+	fmt.Fprintf(h.writer, "%s%-5s%s %s", levelColor, r.Level.String(), ansiReset, r.Message)
+
+	for _, attr := range h.attrs {
+		fmt.Fprintf(h.writer, " %s=%v", attr.Key, attr.Value.Any())
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		fmt.Fprintf(h.writer, " %s=%v", h.groupPrefix+a.Key, a.Value.Any())
+		return true
+	})
+	fmt.Fprintln(h.writer)
+	return nil
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	newAttrs := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	newAttrs = append(newAttrs, h.attrs...)
+	for _, a := range attrs {
+		newAttrs = append(newAttrs, slog.Attr{Key: h.groupPrefix + a.Key, Value: a.Value})
+	}
+	return &consoleHandler{writer: h.writer, opts: h.opts, attrs: newAttrs, groupPrefix: h.groupPrefix}
+}
+
+func (h *consoleHandler) WithGroup(name string) slog.Handler {
+	return &consoleHandler{writer: h.writer, opts: h.opts, attrs: h.attrs, groupPrefix: h.groupPrefix + name + "."}
+}
+
+// levelColor returns the ANSI color code used to highlight level.
+func levelColor(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return ansiRed
+	case level >= slog.LevelWarn:
+		return ansiYellow
+	case level >= slog.LevelInfo:
+		return ansiBlue
+	default:
+		return ansiGray
+	}
+}
+
+// traceContextHandler wraps an slog.Handler, adding trace_id/span_id
+// attributes drawn from the OpenTelemetry span active on the context passed
+// to Handle. Records logged with a context that carries no valid span pass
+// through unchanged.
+type traceContextHandler struct {
+	next slog.Handler
+}
+
+// WithTraceContext wraps handler so that any record logged with a context
+// carrying an active OpenTelemetry span has trace_id/span_id attributes
+// added to it, letting schema-generation logs be correlated with a
+// surrounding distributed trace.
+func WithTraceContext(handler slog.Handler) slog.Handler {
+	return &traceContextHandler{next: handler}
+}
+
+func (h *traceContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *traceContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *traceContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &traceContextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *traceContextHandler) WithGroup(name string) slog.Handler {
+	return &traceContextHandler{next: h.next.WithGroup(name)}
+}
@@ -6,6 +6,7 @@ package logging
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"time"
@@ -16,9 +17,10 @@ var globalLogger *slog.Logger
 
 // Common logging keys used for structured logging
 const (
-	ComponentKey = "component" // Key used for component/module identification
-	OperationKey = "operation" // Key used for operation names
-	ErrorKey     = "error"     // Key used for error messages
+	ComponentKey = "component"  // Key used for component/module identification
+	OperationKey = "operation"  // Key used for operation names
+	ErrorKey     = "error"      // Key used for error messages
+	DurationKey  = "elapsed_ms" // Key used for operation duration, in milliseconds
 )
 
 // contextKey is a custom type for context keys to avoid collisions
@@ -27,11 +29,64 @@ type contextKey string
 // operationContextKey is used to store operation names in context
 const operationContextKey = contextKey("operation")
 
-// initLogger creates and configures a new slog.Logger instance with JSON output
-// and custom timestamp formatting. It reads the log level from environment variables.
-func initLogger() *slog.Logger {
+// Format identifies the on-disk representation a handler built by
+// newHandler writes records in.
+type Format string
+
+// Supported log formats. Text and JSON map directly onto slog's built-in
+// handlers; Console is a colorized, human-oriented handler of our own.
+const (
+	FormatText    Format = "text"
+	FormatJSON    Format = "json"
+	FormatConsole Format = "console"
+)
+
+// LoggerConfig describes how to build the global logger: which format to
+// render records in, where to write them, and at what level. Zero-value
+// fields mean JSON on stdout at info level; newHandler does not read the
+// environment itself (defaultLoggerConfig fills the fields from LOG_FORMAT,
+// LOG_LEVEL and LOG_VMODULE for initLogger).
+type LoggerConfig struct {
+	Format Format
+	Writer io.Writer
+	Level  slog.Level
+	// AddSource, when true, asks the underlying slog handler to include the
+	// source file/line of each log call.
+	AddSource bool
+	// VModule holds per-component level overrides (see VModuleHandler). A
+	// nil map disables per-component filtering entirely.
+	VModule map[string]slog.Level
+}
+
+// defaultLoggerConfig returns the LoggerConfig initLogger has historically
+// used, reading LOG_FORMAT, LOG_LEVEL, and LOG_VMODULE from the environment.
+func defaultLoggerConfig() LoggerConfig {
+	vmodule, err := LoadVModuleFromEnv()
+	if err != nil {
+		vmodule = nil
+	}
+
+	return LoggerConfig{
+		Format:  getFormatFromEnv(),
+		Writer:  os.Stdout,
+		Level:   getLevelFromEnv(),
+		VModule: vmodule,
+	}
+}
+
+// newHandler builds the slog.Handler described by cfg, applying the
+// timestamp normalization initLogger has always used and wrapping it with
+// WithTraceContext so every record picks up trace/span IDs when the
+// context it's logged with carries an active OpenTelemetry span.
+func newHandler(cfg LoggerConfig) slog.Handler {
+	writer := cfg.Writer
+	if writer == nil {
+		writer = os.Stdout
+	}
+
 	opts := &slog.HandlerOptions{
-		Level: getLevelFromEnv(),
+		Level:     cfg.Level,
+		AddSource: cfg.AddSource,
 		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
 			if a.Key == slog.TimeKey {
 				a.Key = "timestamp"
@@ -43,8 +98,32 @@ func initLogger() *slog.Logger {
 		},
 	}
 
-	handler := slog.NewJSONHandler(os.Stdout, opts)
-	globalLogger = slog.New(handler)
+	var handler slog.Handler
+	switch cfg.Format {
+	case FormatText:
+		handler = slog.NewTextHandler(writer, opts)
+	case FormatConsole:
+		handler = newConsoleHandler(writer, opts)
+	default:
+		handler = slog.NewJSONHandler(writer, opts)
+	}
+
+	handler = WithTraceContext(handler)
+
+	if len(cfg.VModule) > 0 {
+		vmodule := NewVModuleHandler(handler, cfg.Level)
+		vmodule.Reload(cfg.VModule, cfg.Level)
+		handler = vmodule
+	}
+
+	return handler
+}
+
+// initLogger creates and configures the global slog.Logger from
+// defaultLoggerConfig, i.e. LOG_FORMAT/LOG_LEVEL environment variables with
+// a JSON-on-stdout fallback.
+func initLogger() *slog.Logger {
+	globalLogger = slog.New(newHandler(defaultLoggerConfig()))
 	return globalLogger
 }
 
@@ -56,6 +135,14 @@ func GetLogger() *slog.Logger {
 	return globalLogger
 }
 
+// SetLogger replaces the global logger with logger. Library consumers can
+// use this to inject their own handler (for example one built with a
+// different LoggerConfig, or wired into a logging pipeline this package
+// doesn't know about) instead of relying on environment variables.
+func SetLogger(logger *slog.Logger) {
+	globalLogger = logger
+}
+
 // WithComponent creates a new logger with the specified component name added
 // to all log entries.
 func WithComponent(ctx context.Context, component string) *slog.Logger {
@@ -80,19 +167,24 @@ func LogError(ctx context.Context, err error, msg string, args ...any) error {
 	return fmt.Errorf("%s: %w", msg, err)
 }
 
-// LogOperation wraps an operation with standardized logging
+// LogOperation wraps an operation with standardized logging, emitting a
+// start event, an end event (success or failure), and the operation's
+// duration in milliseconds on the end event.
 func LogOperation[T any](ctx context.Context, operation string, fn func() (T, error)) (T, error) {
 	logger := GetLogger()
 
 	// Create a new context with operation info
 	opCtx := WithOperation(ctx, operation)
 
+	start := time.Now()
 	logger.InfoContext(opCtx, "starting operation", OperationKey, operation)
 
 	result, err := fn()
+	elapsedMS := time.Since(start).Milliseconds()
 	if err != nil {
 		logger.ErrorContext(opCtx, "operation failed",
 			OperationKey, operation,
+			DurationKey, elapsedMS,
 			ErrorKey, err,
 		)
 		return result, fmt.Errorf("%s: %w", operation, err)
@@ -100,6 +192,7 @@ func LogOperation[T any](ctx context.Context, operation string, fn func() (T, er
 
 	logger.InfoContext(opCtx, "operation completed",
 		OperationKey, operation,
+		DurationKey, elapsedMS,
 	)
 	return result, nil
 }
@@ -121,3 +214,19 @@ func getLevelFromEnv() slog.Level {
 		return slog.LevelInfo
 	}
 }
+
+// getFormatFromEnv reads and parses the LOG_FORMAT environment variable to
+// determine which handler format to use. Defaults to JSON if not set or
+// invalid, matching this package's historical behavior.
+func getFormatFromEnv() Format {
+	switch os.Getenv("LOG_FORMAT") {
+	case "text":
+		return FormatText
+	case "console":
+		return FormatConsole
+	case "json":
+		return FormatJSON
+	default:
+		return FormatJSON
+	}
+}
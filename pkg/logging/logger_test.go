@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestGetLogger(t *testing.T) {
@@ -126,6 +127,148 @@ func TestLogOperation(t *testing.T) {
 	}
 }
 
+func TestSetLogger(t *testing.T) {
+	var buf bytes.Buffer
+	custom := slog.New(slog.NewTextHandler(&buf, nil))
+
+	SetLogger(custom)
+	defer func() { globalLogger = nil }()
+
+	if GetLogger() != custom {
+		t.Fatal("SetLogger didn't make GetLogger return the injected logger")
+	}
+
+	GetLogger().Info("hello")
+	if !bytes.Contains(buf.Bytes(), []byte("hello")) {
+		t.Errorf("expected injected logger to receive the log line, got:\n%s", buf.String())
+	}
+}
+
+func TestGetFormatFromEnv(t *testing.T) {
+	tests := []struct {
+		envValue string
+		expected Format
+	}{
+		{"text", FormatText},
+		{"console", FormatConsole},
+		{"json", FormatJSON},
+		{"invalid", FormatJSON},
+		{"", FormatJSON},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.envValue, func(t *testing.T) {
+			if tc.envValue != "" {
+				os.Setenv("LOG_FORMAT", tc.envValue)
+				defer os.Unsetenv("LOG_FORMAT")
+			} else {
+				os.Unsetenv("LOG_FORMAT")
+			}
+
+			if got := getFormatFromEnv(); got != tc.expected {
+				t.Errorf("getFormatFromEnv with LOG_FORMAT=%q returned %v, expected %v", tc.envValue, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNewHandlerFormats(t *testing.T) {
+	var buf bytes.Buffer
+
+	textLogger := slog.New(newHandler(LoggerConfig{Format: FormatText, Writer: &buf}))
+	textLogger.Info("text message")
+	if !bytes.Contains(buf.Bytes(), []byte("msg=\"text message\"")) {
+		t.Errorf("expected text handler output, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	jsonLogger := slog.New(newHandler(LoggerConfig{Format: FormatJSON, Writer: &buf}))
+	jsonLogger.Info("json message")
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected valid JSON output, got: %v\n%s", err, buf.String())
+	}
+	if entry["msg"] != "json message" {
+		t.Errorf("expected json handler to log the message, got: %v", entry)
+	}
+
+	buf.Reset()
+	consoleLogger := slog.New(newHandler(LoggerConfig{Format: FormatConsole, Writer: &buf}))
+	consoleLogger.Info("console message")
+	if !bytes.Contains(buf.Bytes(), []byte("console message")) {
+		t.Errorf("expected console handler output, got:\n%s", buf.String())
+	}
+}
+
+func TestConsoleHandlerWithGroupQualifiesKeys(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(newConsoleHandler(&buf, nil))
+
+	logger.WithGroup("db").With("attempt", 1).Info("connecting", "host", "db.internal")
+	if !bytes.Contains(buf.Bytes(), []byte("db.attempt=1")) {
+		t.Errorf("expected a WithAttrs attr added after WithGroup to be qualified as db.attempt, got:\n%s", buf.String())
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("db.host=db.internal")) {
+		t.Errorf("expected a record attr added after WithGroup to be qualified as db.host, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	logger = slog.New(newConsoleHandler(&buf, nil)).With("service", "api")
+	logger.WithGroup("db").Info("connecting")
+	if !bytes.Contains(buf.Bytes(), []byte(" service=api")) {
+		t.Errorf("expected an attr added before WithGroup to stay unqualified, got:\n%s", buf.String())
+	}
+}
+
+func TestWithTraceContextPassesThroughWithoutSpan(t *testing.T) {
+	var buf bytes.Buffer
+	handler := WithTraceContext(slog.NewJSONHandler(&buf, nil))
+	logger := slog.New(handler)
+
+	logger.Info("no span")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log entry: %v", err)
+	}
+	if _, ok := entry["trace_id"]; ok {
+		t.Errorf("expected no trace_id without an active span, got: %v", entry)
+	}
+}
+
+func TestLogOperationRecordsElapsed(t *testing.T) {
+	var buf bytes.Buffer
+	globalLogger = slog.New(slog.NewJSONHandler(&buf, nil))
+	defer func() { globalLogger = nil }()
+
+	ctx := context.Background()
+	_, err := LogOperation(ctx, "timed-op", func() (string, error) {
+		time.Sleep(time.Millisecond)
+		return "done", nil
+	})
+	if err != nil {
+		t.Fatalf("LogOperation returned unexpected error: %v", err)
+	}
+
+	dec := json.NewDecoder(&buf)
+	var sawCompletionWithDuration bool
+	for {
+		var entry map[string]any
+		if decErr := dec.Decode(&entry); decErr != nil {
+			break
+		}
+		if entry["msg"] == "operation completed" {
+			if _, ok := entry[DurationKey]; !ok {
+				t.Errorf("expected %s attribute on completion log, got: %v", DurationKey, entry)
+			}
+			sawCompletionWithDuration = true
+		}
+	}
+	if !sawCompletionWithDuration {
+		t.Fatal("expected an 'operation completed' log entry")
+	}
+}
+
 func TestGetLevelFromEnv(t *testing.T) {
 	tests := []struct {
 		envValue string
@@ -0,0 +1,164 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// VModuleEnvVar is the environment variable read for per-component level
+// overrides, e.g. "jsonschema=debug,helm=warn". The name and syntax mirror
+// the vmodule flag popularized by glog and later adopted by go-ethereum's
+// slog migration.
+const VModuleEnvVar = "LOG_VMODULE"
+
+// vmoduleRules holds the component->level overrides a VModuleHandler
+// consults, guarded by a mutex so Reload can swap them in while handlers
+// built from the same VModuleHandler are actively logging.
+type vmoduleRules struct {
+	mu           sync.RWMutex
+	rules        map[string]slog.Level
+	defaultLevel slog.Level
+}
+
+func (r *vmoduleRules) levelFor(component string) slog.Level {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if component != "" {
+		if level, ok := r.rules[component]; ok {
+			return level
+		}
+	}
+	return r.defaultLevel
+}
+
+// minLevel returns the most verbose (lowest) level enabled by any rule,
+// including the default. A record below this level can never be emitted
+// by any component, so Handler.Enabled can reject it outright.
+func (r *vmoduleRules) minLevel() slog.Level {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	min := r.defaultLevel
+	for _, level := range r.rules {
+		if level < min {
+			min = level
+		}
+	}
+	return min
+}
+
+func (r *vmoduleRules) set(rules map[string]slog.Level, defaultLevel slog.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = rules
+	r.defaultLevel = defaultLevel
+}
+
+// VModuleHandler is an slog.Handler that filters records by the level
+// configured for the ComponentKey attribute set via WithComponent (or
+// WithAttrs directly), falling back to a default level for records with no
+// component. Specific component rules always take precedence over the
+// default, regardless of which is more or less verbose.
+type VModuleHandler struct {
+	next      slog.Handler
+	component string
+	shared    *vmoduleRules
+}
+
+// NewVModuleHandler wraps next with per-component level filtering. Records
+// are enabled by default at defaultLevel until Reload adds per-component
+// overrides.
+func NewVModuleHandler(next slog.Handler, defaultLevel slog.Level) *VModuleHandler {
+	return &VModuleHandler{
+		next:   next,
+		shared: &vmoduleRules{rules: map[string]slog.Level{}, defaultLevel: defaultLevel},
+	}
+}
+
+// Reload replaces the handler's per-component rules and default level.
+// Every handler derived from h via WithAttrs/WithGroup shares the same
+// ruleset, so this takes effect immediately across all of them without
+// needing to rebuild the logger.
+func (h *VModuleHandler) Reload(rules map[string]slog.Level, defaultLevel slog.Level) {
+	h.shared.set(rules, defaultLevel)
+}
+
+func (h *VModuleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.shared.minLevel()
+}
+
+func (h *VModuleHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < h.shared.levelFor(h.component) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *VModuleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	component := h.component
+	for _, a := range attrs {
+		if a.Key == ComponentKey {
+			component = a.Value.String()
+		}
+	}
+	return &VModuleHandler{next: h.next.WithAttrs(attrs), component: component, shared: h.shared}
+}
+
+func (h *VModuleHandler) WithGroup(name string) slog.Handler {
+	return &VModuleHandler{next: h.next.WithGroup(name), component: h.component, shared: h.shared}
+}
+
+// ParseVModule parses a vmodule spec of the form
+// "component=level,component2=level2" into a component->level map. Levels
+// use the same names accepted by LOG_LEVEL (debug/info/warn/error, any
+// case). An empty spec returns an empty, non-nil map.
+func ParseVModule(spec string) (map[string]slog.Level, error) {
+	rules := map[string]slog.Level{}
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return rules, nil
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		component, levelName, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid vmodule entry %q: expected component=level", entry)
+		}
+		component = strings.TrimSpace(component)
+		level, ok := parseLevelName(strings.TrimSpace(levelName))
+		if !ok {
+			return nil, fmt.Errorf("invalid vmodule entry %q: unknown level %q", entry, levelName)
+		}
+		rules[component] = level
+	}
+	return rules, nil
+}
+
+// LoadVModuleFromEnv parses VModuleEnvVar, returning an empty map if it's unset.
+func LoadVModuleFromEnv() (map[string]slog.Level, error) {
+	return ParseVModule(os.Getenv(VModuleEnvVar))
+}
+
+// parseLevelName maps a level name (as accepted by LOG_LEVEL) to a
+// slog.Level, reporting false for anything unrecognized.
+func parseLevelName(name string) (slog.Level, bool) {
+	switch strings.ToUpper(name) {
+	case "DEBUG":
+		return slog.LevelDebug, true
+	case "INFO":
+		return slog.LevelInfo, true
+	case "WARN":
+		return slog.LevelWarn, true
+	case "ERROR":
+		return slog.LevelError, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,106 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestParseVModule(t *testing.T) {
+	rules, err := ParseVModule("jsonschema=debug, helm=warn")
+	if err != nil {
+		t.Fatalf("ParseVModule returned an error: %v", err)
+	}
+
+	if rules["jsonschema"] != slog.LevelDebug {
+		t.Errorf("expected jsonschema=debug, got %v", rules["jsonschema"])
+	}
+	if rules["helm"] != slog.LevelWarn {
+		t.Errorf("expected helm=warn, got %v", rules["helm"])
+	}
+}
+
+func TestParseVModuleEmpty(t *testing.T) {
+	rules, err := ParseVModule("")
+	if err != nil {
+		t.Fatalf("ParseVModule returned an error: %v", err)
+	}
+	if len(rules) != 0 {
+		t.Errorf("expected an empty ruleset, got %v", rules)
+	}
+}
+
+func TestParseVModuleInvalid(t *testing.T) {
+	if _, err := ParseVModule("jsonschema"); err == nil {
+		t.Fatal("expected an error for an entry missing '='")
+	}
+	if _, err := ParseVModule("jsonschema=verbose"); err == nil {
+		t.Fatal("expected an error for an unknown level name")
+	}
+}
+
+func TestLoadVModuleFromEnv(t *testing.T) {
+	os.Setenv("LOG_VMODULE", "cli=error")
+	defer os.Unsetenv("LOG_VMODULE")
+
+	rules, err := LoadVModuleFromEnv()
+	if err != nil {
+		t.Fatalf("LoadVModuleFromEnv returned an error: %v", err)
+	}
+	if rules["cli"] != slog.LevelError {
+		t.Errorf("expected cli=error, got %v", rules["cli"])
+	}
+}
+
+func TestVModuleHandlerComponentPrecedence(t *testing.T) {
+	var buf bytes.Buffer
+	inner := slog.NewJSONHandler(&buf, nil)
+	handler := NewVModuleHandler(inner, slog.LevelInfo)
+	handler.Reload(map[string]slog.Level{"jsonschema": slog.LevelDebug, "helm": slog.LevelWarn}, slog.LevelInfo)
+
+	ctx := context.Background()
+
+	jsonschemaLogger := slog.New(handler).With(ComponentKey, "jsonschema")
+	jsonschemaLogger.DebugContext(ctx, "debug from jsonschema")
+	if !bytes.Contains(buf.Bytes(), []byte("debug from jsonschema")) {
+		t.Errorf("expected jsonschema's debug-level override to let the record through, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	helmLogger := slog.New(handler).With(ComponentKey, "helm")
+	helmLogger.InfoContext(ctx, "info from helm")
+	if bytes.Contains(buf.Bytes(), []byte("info from helm")) {
+		t.Errorf("expected helm's warn-level override to suppress an info record, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	defaultLogger := slog.New(handler)
+	defaultLogger.InfoContext(ctx, "info from default component")
+	if !bytes.Contains(buf.Bytes(), []byte("info from default component")) {
+		t.Errorf("expected the default level to let an info record with no component through, got:\n%s", buf.String())
+	}
+}
+
+func TestVModuleHandlerReload(t *testing.T) {
+	var buf bytes.Buffer
+	inner := slog.NewJSONHandler(&buf, nil)
+	handler := NewVModuleHandler(inner, slog.LevelInfo)
+
+	ctx := context.Background()
+	cliLogger := slog.New(handler).With(ComponentKey, "cli")
+
+	cliLogger.DebugContext(ctx, "before reload")
+	if bytes.Contains(buf.Bytes(), []byte("before reload")) {
+		t.Errorf("expected debug to be suppressed before reload, got:\n%s", buf.String())
+	}
+
+	handler.Reload(map[string]slog.Level{"cli": slog.LevelDebug}, slog.LevelInfo)
+
+	buf.Reset()
+	cliLogger.DebugContext(ctx, "after reload")
+	if !bytes.Contains(buf.Bytes(), []byte("after reload")) {
+		t.Errorf("expected debug to be let through after reload added a cli=debug rule, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,32 @@
+package lsp
+
+import (
+	"fmt"
+	"sort"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+// completionsAt returns one completionItem per key of the schema mapping
+// that encloses (line, character) - the sibling keys of whatever the user
+// is currently typing - sorted by label for a stable, predictable order in
+// the editor's completion list.
+func completionsAt(schema *jsonschema.Schema, index jsonschema.PositionIndex, line, character int) []completionItem {
+	parent := schemaAtPath(schema, enclosingPath(index, line, character))
+	if parent == nil || len(parent.Properties) == 0 {
+		return nil
+	}
+
+	items := make([]completionItem, 0, len(parent.Properties))
+	for key, propSchema := range parent.Properties {
+		items = append(items, completionItem{
+			Label:         key,
+			Kind:          completionItemKindProperty,
+			Detail:        fmt.Sprint(propSchema.Type),
+			Documentation: propSchema.Description,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].Label < items[j].Label })
+	return items
+}
@@ -0,0 +1,60 @@
+package lsp
+
+import (
+	"context"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+// diagnose generates a schema from text and validates it with
+// ValidateHelmBestPractices, resolving each ValidationIssue to a source
+// position via jsonschema.BuildPositionIndex. A YAML parse or generation
+// failure is reported as a single diagnostic at the top of the file instead
+// of failing outright, so the client still gets feedback while the user is
+// mid-edit; in that case the returned schema and index are nil, and the
+// caller (see Server.openOrUpdate) keeps serving hover/completion off the
+// last version that parsed cleanly.
+func diagnose(ctx context.Context, generator *jsonschema.Generator, text string) (*jsonschema.Schema, jsonschema.PositionIndex, []diagnostic) {
+	data := []byte(text)
+
+	schema, err := generator.GenerateFromYAML(ctx, data)
+	if err != nil {
+		return nil, nil, []diagnostic{{
+			Severity: severityError,
+			Source:   "helm-schema-gen",
+			Message:  err.Error(),
+		}}
+	}
+
+	index, err := jsonschema.BuildPositionIndex(data)
+	if err != nil {
+		index = jsonschema.PositionIndex{}
+	}
+
+	issues := jsonschema.AttachPositions(jsonschema.ValidateHelmBestPractices(schema), index)
+	diagnostics := make([]diagnostic, 0, len(issues))
+	for _, issue := range issues {
+		pos := position{Line: issue.Position.Line, Character: issue.Position.Column}
+		diagnostics = append(diagnostics, diagnostic{
+			Range:    lspRange{Start: pos, End: pos},
+			Severity: severityFromLevel(issue.Level),
+			Source:   "helm-schema-gen",
+			Message:  issue.Message,
+		})
+	}
+
+	return schema, index, diagnostics
+}
+
+// severityFromLevel maps a jsonschema.ValidationLevel to its LSP
+// DiagnosticSeverity equivalent.
+func severityFromLevel(level jsonschema.ValidationLevel) int {
+	switch level {
+	case jsonschema.Error:
+		return severityError
+	case jsonschema.Warning:
+		return severityWarning
+	default:
+		return severityInformation
+	}
+}
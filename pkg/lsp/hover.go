@@ -0,0 +1,40 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+// hoverContent renders a short Markdown summary of schema's inferred type,
+// free-text description, and any format/enum/default/examples constraints -
+// the same fields ValidateHelmBestPractices and the JSON Schema output
+// itself are built from, so hover never tells the user something the
+// generated schema doesn't already say.
+func hoverContent(schema *jsonschema.Schema) string {
+	if schema == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**type**: `%v`\n\n", schema.Type)
+	if schema.Description != "" {
+		b.WriteString(schema.Description)
+		b.WriteString("\n\n")
+	}
+	if schema.Format != "" {
+		fmt.Fprintf(&b, "**format**: `%s`\n\n", schema.Format)
+	}
+	if len(schema.Enum) > 0 {
+		fmt.Fprintf(&b, "**enum**: `%v`\n\n", schema.Enum)
+	}
+	if schema.Default != nil {
+		fmt.Fprintf(&b, "**default**: `%v`\n\n", schema.Default)
+	}
+	if len(schema.Examples) > 0 {
+		fmt.Fprintf(&b, "**examples**: `%v`\n\n", schema.Examples)
+	}
+
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,92 @@
+// Package lsp implements a minimal Language Server Protocol server (stdio
+// transport) for editing Helm values.yaml files, backed by the schema
+// generator and ValidateHelmBestPractices: textDocument/publishDiagnostics
+// surfaces ValidationIssue entries resolved to precise YAML positions,
+// textDocument/hover shows the inferred type, description, and examples for
+// the key under the cursor, and textDocument/completion suggests sibling
+// keys from the generated schema.
+//
+// The server intentionally never goes through pkg/logging: that package
+// writes JSON log lines to stdout, the same stream the LSP stdio transport
+// uses for protocol frames, and interleaving the two would corrupt the
+// connection. Diagnostic output from the server itself goes to stderr.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// message is a JSON-RPC 2.0 request, response, or notification as exchanged
+// over the LSP stdio transport. A request carries a non-nil ID and a
+// Method; a response carries the same ID and a Result or Error instead; a
+// notification carries a nil ID.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC 2.0 error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// readMessage reads one "Content-Length: N\r\n\r\n<N bytes of JSON>" frame
+// from r - the header-plus-body framing every LSP transport variant (stdio,
+// TCP, named pipe) uses regardless of payload.
+func readMessage(r *bufio.Reader) (*message, error) {
+	contentLength := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if name, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			contentLength, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length header %q: %w", value, err)
+			}
+		}
+	}
+	if contentLength < 0 {
+		return nil, fmt.Errorf("message is missing a Content-Length header")
+	}
+
+	body := make([]byte, contentLength)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, fmt.Errorf("failed to read message body: %w", err)
+	}
+
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON-RPC message: %w", err)
+	}
+	return &msg, nil
+}
+
+// writeMessage frames msg with a Content-Length header and writes it to w.
+func writeMessage(w io.Writer, msg message) error {
+	msg.JSONRPC = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON-RPC message: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
@@ -0,0 +1,38 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestMessageRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	params, _ := json.Marshal(map[string]string{"foo": "bar"})
+
+	if err := writeMessage(&buf, message{ID: json.RawMessage("1"), Method: "textDocument/hover", Params: params}); err != nil {
+		t.Fatalf("writeMessage returned an error: %v", err)
+	}
+
+	got, err := readMessage(bufio.NewReader(&buf))
+	if err != nil {
+		t.Fatalf("readMessage returned an error: %v", err)
+	}
+	if got.Method != "textDocument/hover" {
+		t.Errorf("expected method textDocument/hover, got %q", got.Method)
+	}
+	if string(got.ID) != "1" {
+		t.Errorf("expected id 1, got %s", got.ID)
+	}
+	if !bytes.Equal(got.Params, params) {
+		t.Errorf("expected params %s, got %s", params, got.Params)
+	}
+}
+
+func TestReadMessageMissingContentLength(t *testing.T) {
+	r := bufio.NewReader(bytes.NewBufferString("\r\n{}"))
+	if _, err := readMessage(r); err == nil {
+		t.Error("expected an error for a message with no Content-Length header")
+	}
+}
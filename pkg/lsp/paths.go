@@ -0,0 +1,85 @@
+package lsp
+
+import (
+	"strings"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+// pathAtLine returns the dotted Helm path (see jsonschema.BuildPositionIndex)
+// whose key, or sequence item, starts on line and at or before character -
+// the innermost entry the cursor is on, since a mapping key and a scalar
+// value commonly share one line ("image: nginx").
+func pathAtLine(index jsonschema.PositionIndex, line, character int) (string, bool) {
+	best := ""
+	bestColumn := -1
+	found := false
+	for path, pos := range index {
+		if pos.Line != line || pos.Column > character {
+			continue
+		}
+		if pos.Column > bestColumn {
+			bestColumn = pos.Column
+			best = path
+			found = true
+		}
+	}
+	return best, found
+}
+
+// enclosingPath returns the dotted path of the key that opened the mapping
+// the cursor at (line, character) is currently inside: the nearest
+// preceding key on an earlier line whose own indentation is shallower than
+// the cursor's, the same indentation-based scoping rule YAML editors use to
+// infer "what node am I a child of".
+func enclosingPath(index jsonschema.PositionIndex, line, character int) string {
+	best := ""
+	bestLine := -1
+	for path, pos := range index {
+		if pos.Line >= line || pos.Column >= character {
+			continue
+		}
+		if pos.Line > bestLine {
+			bestLine = pos.Line
+			best = path
+		}
+	}
+	return best
+}
+
+// schemaAtPath walks root's Properties (and, across a "[N]" segment, Items)
+// following path's dotted/bracketed segments - the same convention
+// jsonschema.BuildPositionIndex and CommentExtractor use to build Helm
+// paths - returning nil if any segment isn't present in the schema.
+func schemaAtPath(root *jsonschema.Schema, path string) *jsonschema.Schema {
+	if path == "" {
+		return root
+	}
+
+	current := root
+	for _, segment := range strings.Split(path, ".") {
+		if current == nil {
+			return nil
+		}
+
+		key, brackets, _ := strings.Cut(segment, "[")
+		if key != "" {
+			if current.Properties == nil {
+				return nil
+			}
+			current = current.Properties[key]
+		}
+		for brackets != "" {
+			if current == nil {
+				return nil
+			}
+			_, rest, _ := strings.Cut(brackets, "]")
+			current = current.Items
+			brackets = strings.TrimPrefix(rest, "[")
+			if rest == brackets {
+				break
+			}
+		}
+	}
+	return current
+}
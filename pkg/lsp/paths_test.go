@@ -0,0 +1,72 @@
+package lsp
+
+import (
+	"testing"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+func TestSchemaAtPath(t *testing.T) {
+	root := &jsonschema.Schema{
+		Type: jsonschema.TypeObject,
+		Properties: map[string]*jsonschema.Schema{
+			"image": {
+				Type: jsonschema.TypeObject,
+				Properties: map[string]*jsonschema.Schema{
+					"repository": {Type: jsonschema.TypeString},
+				},
+			},
+			"ports": {
+				Type: jsonschema.TypeArray,
+				Items: &jsonschema.Schema{
+					Type: jsonschema.TypeObject,
+					Properties: map[string]*jsonschema.Schema{
+						"name": {Type: jsonschema.TypeString},
+					},
+				},
+			},
+		},
+	}
+
+	if got := schemaAtPath(root, ""); got != root {
+		t.Errorf("expected the empty path to resolve to root, got %v", got)
+	}
+	if got := schemaAtPath(root, "image.repository"); got == nil || got.Type != jsonschema.TypeString {
+		t.Errorf("expected image.repository to resolve to a string schema, got %v", got)
+	}
+	if got := schemaAtPath(root, "ports[0].name"); got == nil || got.Type != jsonschema.TypeString {
+		t.Errorf("expected ports[0].name to resolve to a string schema, got %v", got)
+	}
+	if got := schemaAtPath(root, "missing.path"); got != nil {
+		t.Errorf("expected an unknown path to resolve to nil, got %v", got)
+	}
+}
+
+func TestPathAtLine(t *testing.T) {
+	index := jsonschema.PositionIndex{
+		"image":            {Line: 0, Column: 0},
+		"image.repository": {Line: 1, Column: 2},
+	}
+
+	path, ok := pathAtLine(index, 1, 10)
+	if !ok || path != "image.repository" {
+		t.Errorf("expected image.repository at line 1, got %q (ok=%v)", path, ok)
+	}
+
+	if _, ok := pathAtLine(index, 5, 0); ok {
+		t.Error("expected no match for a line with no entries")
+	}
+}
+
+func TestEnclosingPath(t *testing.T) {
+	index := jsonschema.PositionIndex{
+		"image":            {Line: 0, Column: 0},
+		"image.repository": {Line: 1, Column: 2},
+	}
+
+	// The cursor is on a new, shallow-indented line after "image.repository"
+	// - its enclosing mapping is "image", not "image.repository".
+	if got := enclosingPath(index, 2, 2); got != "image" {
+		t.Errorf("expected enclosing path 'image', got %q", got)
+	}
+}
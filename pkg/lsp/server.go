@@ -0,0 +1,212 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+// document is one open values.yaml buffer tracked by the server, keyed by
+// its LSP URI.
+type document struct {
+	text   string
+	schema *jsonschema.Schema
+	index  jsonschema.PositionIndex
+}
+
+// Server is a stdio LSP server for values.yaml editing, backed by a single
+// jsonschema.Generator shared across every open document. It has no
+// dependency on pkg/cli; the "lsp" subcommand (see pkg/cli/lsp.go) builds
+// GeneratorOptions from the usual Options and hands them, with the rules
+// file path, to NewServer, which creates the Generator.
+type Server struct {
+	generator *jsonschema.Generator
+	documents map[string]*document
+
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewServer creates a Server around a generator built from genOpts and
+// rulesPath (see jsonschema.NewGeneratorFromConfig), reading requests from
+// stdin and writing responses/notifications to stdout.
+func NewServer(genOpts jsonschema.GeneratorOptions, rulesPath string) (*Server, error) {
+	generator, err := jsonschema.NewGeneratorFromConfig(genOpts, rulesPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schema generator: %w", err)
+	}
+
+	return &Server{
+		generator: generator,
+		documents: make(map[string]*document),
+		in:        bufio.NewReader(os.Stdin),
+		out:       os.Stdout,
+	}, nil
+}
+
+// Run serves JSON-RPC requests and notifications until the client sends
+// "exit", stdin closes, or ctx is canceled.
+func (s *Server) Run(ctx context.Context) error {
+	for ctx.Err() == nil {
+		msg, err := readMessage(s.in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read LSP message: %w", err)
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		s.dispatch(ctx, msg)
+	}
+	return ctx.Err()
+}
+
+// dispatch handles one request or notification. Unrecognized notifications
+// are silently ignored, per the LSP spec; an unrecognized request gets an
+// empty success response rather than an error, since most clients treat a
+// missing optional capability the same way either way.
+func (s *Server) dispatch(ctx context.Context, msg *message) {
+	switch msg.Method {
+	case "initialize":
+		s.reply(msg.ID, initializeResult())
+
+	case "initialized", "$/cancelRequest", "workspace/didChangeConfiguration":
+		// No-op notifications.
+
+	case "shutdown":
+		s.reply(msg.ID, nil)
+
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			fmt.Fprintf(os.Stderr, "lsp: malformed didOpen params: %v\n", err)
+			return
+		}
+		s.openOrUpdate(ctx, params.TextDocument.URI, params.TextDocument.Text)
+
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			fmt.Fprintf(os.Stderr, "lsp: malformed didChange params: %v\n", err)
+			return
+		}
+		if len(params.ContentChanges) == 0 {
+			return
+		}
+		// TextDocumentSyncKindFull (see initializeResult) means the last
+		// entry always carries the whole document.
+		s.openOrUpdate(ctx, params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := json.Unmarshal(msg.Params, &params); err == nil {
+			delete(s.documents, params.TextDocument.URI)
+		}
+
+	case "textDocument/hover":
+		s.handleHover(msg)
+
+	case "textDocument/completion":
+		s.handleCompletion(msg)
+
+	default:
+		if msg.ID != nil {
+			s.reply(msg.ID, nil)
+		}
+	}
+}
+
+// openOrUpdate regenerates uri's schema from text and publishes the
+// resulting diagnostics, caching the new schema/index for hover and
+// completion only if generation succeeded.
+func (s *Server) openOrUpdate(ctx context.Context, uri, text string) {
+	schema, index, diagnostics := diagnose(ctx, s.generator, text)
+
+	doc, ok := s.documents[uri]
+	if !ok {
+		doc = &document{}
+		s.documents[uri] = doc
+	}
+	doc.text = text
+	if schema != nil {
+		doc.schema = schema
+		doc.index = index
+	}
+
+	s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics})
+}
+
+func (s *Server) handleHover(msg *message) {
+	var params textDocumentPositionParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		s.reply(msg.ID, nil)
+		return
+	}
+
+	doc := s.documents[params.TextDocument.URI]
+	if doc == nil || doc.schema == nil {
+		s.reply(msg.ID, nil)
+		return
+	}
+
+	path, ok := pathAtLine(doc.index, params.Position.Line, params.Position.Character)
+	if !ok {
+		s.reply(msg.ID, nil)
+		return
+	}
+
+	content := hoverContent(schemaAtPath(doc.schema, path))
+	if content == "" {
+		s.reply(msg.ID, nil)
+		return
+	}
+	s.reply(msg.ID, hoverResult{Contents: content})
+}
+
+func (s *Server) handleCompletion(msg *message) {
+	var params textDocumentPositionParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		s.reply(msg.ID, []completionItem{})
+		return
+	}
+
+	doc := s.documents[params.TextDocument.URI]
+	if doc == nil || doc.schema == nil {
+		s.reply(msg.ID, []completionItem{})
+		return
+	}
+
+	s.reply(msg.ID, completionsAt(doc.schema, doc.index, params.Position.Line, params.Position.Character))
+}
+
+// notify sends a server-to-client notification (no ID, no response expected).
+func (s *Server) notify(method string, params any) {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "lsp: failed to encode %s params: %v\n", method, err)
+		return
+	}
+	if err := writeMessage(s.out, message{Method: method, Params: raw}); err != nil {
+		fmt.Fprintf(os.Stderr, "lsp: failed to send %s: %v\n", method, err)
+	}
+}
+
+// reply sends a response to a client request. A nil id means msg was a
+// notification, which gets no response.
+func (s *Server) reply(id json.RawMessage, result any) {
+	if id == nil {
+		return
+	}
+	if err := writeMessage(s.out, message{ID: id, Result: result}); err != nil {
+		fmt.Fprintf(os.Stderr, "lsp: failed to send response: %v\n", err)
+	}
+}
@@ -0,0 +1,175 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+// newTestServer wires a Server to an in-process pipe pair so a test can act
+// as the client: write requests/notifications to clientOut (the server's
+// stdin) and read the server's responses/notifications from clientIn (the
+// server's stdout).
+func newTestServer(t *testing.T) (srv *Server, clientOut io.Writer, clientIn *bufio.Reader) {
+	t.Helper()
+
+	generator, err := jsonschema.NewGeneratorFromConfig(jsonschema.GeneratorOptions{
+		SchemaVersion:       jsonschema.Draft07,
+		ExtractDescriptions: true,
+	}, "")
+	if err != nil {
+		t.Fatalf("failed to build generator: %v", err)
+	}
+
+	serverStdinR, serverStdinW := io.Pipe()
+	serverStdoutR, serverStdoutW := io.Pipe()
+
+	srv = &Server{
+		generator: generator,
+		documents: make(map[string]*document),
+		in:        bufio.NewReader(serverStdinR),
+		out:       serverStdoutW,
+	}
+
+	t.Cleanup(func() {
+		serverStdinW.Close()
+		serverStdoutR.Close()
+	})
+
+	return srv, serverStdinW, bufio.NewReader(serverStdoutR)
+}
+
+func sendRequest(t *testing.T, w io.Writer, id int, method string, params any) {
+	t.Helper()
+	raw, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("failed to encode params: %v", err)
+	}
+	idRaw, _ := json.Marshal(id)
+	if err := writeMessage(w, message{ID: idRaw, Method: method, Params: raw}); err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+}
+
+func TestServerDidOpenPublishesDiagnostics(t *testing.T) {
+	srv, clientOut, clientIn := newTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = srv.Run(ctx) }()
+
+	sendRequest(t, clientOut, 0, "textDocument/didOpen", didOpenParams{
+		TextDocument: textDocumentItem{
+			URI:  "file:///values.yaml",
+			Text: "replicaCount: 1\n",
+		},
+	})
+
+	msg := readWithTimeout(t, clientIn)
+	if msg.Method != "textDocument/publishDiagnostics" {
+		t.Fatalf("expected a publishDiagnostics notification, got method %q", msg.Method)
+	}
+
+	var params publishDiagnosticsParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		t.Fatalf("failed to decode publishDiagnostics params: %v", err)
+	}
+	if params.URI != "file:///values.yaml" {
+		t.Errorf("expected uri file:///values.yaml, got %s", params.URI)
+	}
+}
+
+func TestServerHoverAndCompletion(t *testing.T) {
+	srv, clientOut, clientIn := newTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = srv.Run(ctx) }()
+
+	sendRequest(t, clientOut, 0, "textDocument/didOpen", didOpenParams{
+		TextDocument: textDocumentItem{
+			URI:  "file:///values.yaml",
+			Text: "image:\n  repository: nginx\n  tag: latest\n",
+		},
+	})
+	readWithTimeout(t, clientIn) // publishDiagnostics from didOpen
+
+	sendRequest(t, clientOut, 1, "textDocument/hover", textDocumentPositionParams{
+		TextDocument: textDocumentIdentifier{URI: "file:///values.yaml"},
+		Position:     position{Line: 1, Character: 4},
+	})
+	hoverMsg := readWithTimeout(t, clientIn)
+	var hover hoverResult
+	if err := json.Unmarshal(toRaw(t, hoverMsg.Result), &hover); err != nil {
+		t.Fatalf("failed to decode hover result: %v", err)
+	}
+	if hover.Contents == "" {
+		t.Error("expected non-empty hover contents for image.repository")
+	}
+
+	// A new, unindented line after "image: ..." is back at the root
+	// mapping, so completion here should suggest "image" itself as a
+	// sibling root key.
+	sendRequest(t, clientOut, 2, "textDocument/completion", textDocumentPositionParams{
+		TextDocument: textDocumentIdentifier{URI: "file:///values.yaml"},
+		Position:     position{Line: 3, Character: 0},
+	})
+	completionMsg := readWithTimeout(t, clientIn)
+	var items []completionItem
+	if err := json.Unmarshal(toRaw(t, completionMsg.Result), &items); err != nil {
+		t.Fatalf("failed to decode completion result: %v", err)
+	}
+
+	found := false
+	for _, item := range items {
+		if item.Label == "image" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected completion to suggest sibling key 'image', got %+v", items)
+	}
+}
+
+// readWithTimeout reads one framed message from r, failing the test if none
+// arrives within a few seconds instead of hanging forever.
+func readWithTimeout(t *testing.T, r *bufio.Reader) *message {
+	t.Helper()
+	type result struct {
+		msg *message
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		msg, err := readMessage(r)
+		done <- result{msg, err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			t.Fatalf("readMessage returned an error: %v", res.err)
+		}
+		return res.msg
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for a message from the server")
+		return nil
+	}
+}
+
+// toRaw re-marshals a decoded `any` (json.Unmarshal into message.Result
+// leaves it as map[string]any/[]any) back to JSON so the test can decode it
+// into a concrete type.
+func toRaw(t *testing.T, v any) json.RawMessage {
+	t.Helper()
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to re-encode result: %v", err)
+	}
+	return raw
+}
@@ -0,0 +1,98 @@
+package lsp
+
+// position is a zero-based line/character location, the convention the LSP
+// spec uses. jsonschema.Position (see pkg/schema-generator/positions.go) is
+// also a zero-based line/column pair, so diagnostics.go converts between
+// the two field-for-field with no adjustment.
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+// Diagnostic severities, per the LSP spec's DiagnosticSeverity enum.
+const (
+	severityError       = 1
+	severityWarning     = 2
+	severityInformation = 3
+)
+
+type diagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type textDocumentItem struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type didOpenParams struct {
+	TextDocument textDocumentItem `json:"textDocument"`
+}
+
+// contentChange is one entry of didChangeParams.ContentChanges. The server
+// advertises TextDocumentSyncKindFull (see initializeResult), so each
+// notification carries exactly one entry holding the document's full text.
+type contentChange struct {
+	Text string `json:"text"`
+}
+
+type didChangeParams struct {
+	TextDocument   textDocumentIdentifier `json:"textDocument"`
+	ContentChanges []contentChange        `json:"contentChanges"`
+}
+
+type didCloseParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+}
+
+type textDocumentPositionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Position     position               `json:"position"`
+}
+
+type publishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []diagnostic `json:"diagnostics"`
+}
+
+// hoverResult's Contents is plain Markdown, which every LSP client renders
+// by default - simpler than the MarkupContent object form for a server
+// that only ever sends Markdown.
+type hoverResult struct {
+	Contents string `json:"contents"`
+}
+
+// completionItemKindProperty is CompletionItemKind.Property from the LSP
+// spec, the closest fit for a Helm values.yaml key.
+const completionItemKindProperty = 10
+
+type completionItem struct {
+	Label         string `json:"label"`
+	Kind          int    `json:"kind,omitempty"`
+	Detail        string `json:"detail,omitempty"`
+	Documentation string `json:"documentation,omitempty"`
+}
+
+// initializeResult advertises the subset of server capabilities this
+// package implements.
+func initializeResult() map[string]any {
+	return map[string]any{
+		"capabilities": map[string]any{
+			"textDocumentSync":   1, // TextDocumentSyncKind.Full
+			"hoverProvider":      true,
+			"completionProvider": map[string]any{"triggerCharacters": []string{":", " "}},
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Install copies the plugin directory at srcDir (which must contain a
+// plugin.yaml) into pluginsDir, under a subdirectory named after the
+// plugin, so it's picked up by a subsequent FindPlugins. Installing from a
+// remote URL or archive, the way Helm's own plugin install does, is left
+// for a future change; for now src must already be a local directory.
+func Install(srcDir, pluginsDir string) (*Plugin, error) {
+	manifest, err := loadManifest(srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plugin manifest in %s: %w", srcDir, err)
+	}
+
+	destDir := filepath.Join(pluginsDir, manifest.Name)
+	if err := requireChildOf(pluginsDir, destDir); err != nil {
+		return nil, fmt.Errorf("plugin manifest has an invalid name %q: %w", manifest.Name, err)
+	}
+	if _, err := os.Stat(destDir); err == nil {
+		return nil, fmt.Errorf("plugin %q is already installed at %s", manifest.Name, destDir)
+	}
+
+	if err := os.MkdirAll(pluginsDir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create plugins directory: %w", err)
+	}
+	if err := copyDir(srcDir, destDir); err != nil {
+		return nil, fmt.Errorf("failed to install plugin %q: %w", manifest.Name, err)
+	}
+
+	return &Plugin{Manifest: *manifest, Dir: destDir}, nil
+}
+
+// requireChildOf reports an error if dest, once cleaned, is not a child of
+// dir - guarding against a manifest whose name is a path traversal (e.g.
+// "../../somewhere/evil") escaping the intended installation directory.
+func requireChildOf(dir, dest string) error {
+	rel, err := filepath.Rel(dir, filepath.Clean(dest))
+	if err != nil {
+		return fmt.Errorf("could not resolve %q relative to %q: %w", dest, dir, err)
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("resolves outside of %q", dir)
+	}
+	return nil
+}
+
+// Remove deletes the installed plugin named name from pluginsDir.
+func Remove(pluginsDir, name string) error {
+	plugins, err := FindPlugins(pluginsDir)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range plugins {
+		if p.Name == name {
+			if err := os.RemoveAll(p.Dir); err != nil {
+				return fmt.Errorf("failed to remove plugin %q: %w", name, err)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("plugin %q is not installed", name)
+}
+
+// copyDir recursively copies src into dst, creating dst and any
+// intermediate directories as needed, and preserving each file's mode.
+func copyDir(src, dst string) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dst, 0750); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		srcPath := filepath.Join(src, entry.Name())
+		dstPath := filepath.Join(dst, entry.Name())
+
+		if entry.IsDir() {
+			if err := copyDir(srcPath, dstPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := copyFile(srcPath, dstPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyFile copies the single file at src to dst, preserving its mode.
+func copyFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(src) // #nosec G304 -- src is a path discovered under a directory the caller asked us to install from
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode()) // #nosec G304 -- dst is computed from the plugins directory, not user input
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
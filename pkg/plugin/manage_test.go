@@ -0,0 +1,73 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInstallAndRemove(t *testing.T) {
+	srcDir := t.TempDir()
+	manifest := "name: installable\nversion: 2.0.0\nentrypoint: run.sh\n"
+	if err := os.WriteFile(filepath.Join(srcDir, manifestFileName), []byte(manifest), 0600); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "run.sh"), []byte("#!/bin/sh\ncat\n"), 0700); err != nil {
+		t.Fatalf("failed to write entrypoint: %v", err)
+	}
+
+	pluginsDir := filepath.Join(t.TempDir(), "plugins")
+
+	installed, err := Install(srcDir, pluginsDir)
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	if installed.Name != "installable" || installed.Version != "2.0.0" {
+		t.Errorf("unexpected installed manifest: %+v", installed.Manifest)
+	}
+	if _, err := os.Stat(installed.EntrypointPath()); err != nil {
+		t.Errorf("expected the entrypoint to be copied, got %v", err)
+	}
+
+	if _, err := Install(srcDir, pluginsDir); err == nil {
+		t.Error("expected installing an already-installed plugin to fail")
+	}
+
+	plugins, err := FindPlugins(pluginsDir)
+	if err != nil || len(plugins) != 1 {
+		t.Fatalf("expected the installed plugin to be discoverable, got %v, %d plugins", err, len(plugins))
+	}
+
+	if err := Remove(pluginsDir, "installable"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := os.Stat(installed.Dir); !os.IsNotExist(err) {
+		t.Errorf("expected the plugin directory to be gone after Remove, got %v", err)
+	}
+
+	if err := Remove(pluginsDir, "installable"); err == nil {
+		t.Error("expected removing a not-installed plugin to fail")
+	}
+}
+
+func TestInstallRejectsPathTraversalName(t *testing.T) {
+	srcDir := t.TempDir()
+	manifest := "name: \"../../evil\"\nversion: 1.0.0\nentrypoint: run.sh\n"
+	if err := os.WriteFile(filepath.Join(srcDir, manifestFileName), []byte(manifest), 0600); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "run.sh"), []byte("#!/bin/sh\ncat\n"), 0700); err != nil {
+		t.Fatalf("failed to write entrypoint: %v", err)
+	}
+
+	pluginsRoot := t.TempDir()
+	pluginsDir := filepath.Join(pluginsRoot, "plugins")
+
+	if _, err := Install(srcDir, pluginsDir); err == nil {
+		t.Fatal("expected Install to reject a manifest name that escapes pluginsDir")
+	}
+
+	if _, err := os.Stat(filepath.Join(pluginsRoot, "evil")); !os.IsNotExist(err) {
+		t.Errorf("expected no files to be written outside pluginsDir, got %v", err)
+	}
+}
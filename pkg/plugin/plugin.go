@@ -0,0 +1,189 @@
+// Package plugin implements helm-schema-gen's plugin subsystem: discovering
+// plugin directories (modeled on Helm's own plugin.FindPlugins scan of
+// settings.PluginsDirectory), and invoking each plugin's entrypoint with a
+// generated schema over stdin/stdout.
+//
+// A plugin is a directory containing a plugin.yaml manifest and an
+// executable entrypoint. Generation invokes the entrypoint once per plugin,
+// writing a JSON envelope of the form {"schema": <json-schema>} to its
+// stdin, and expects one of two envelopes back on stdout:
+//
+//	{"type": "transform", "schema": <json-schema>}
+//	{"type": "validator", "issues": [{"path": "...", "message": "...", "level": "warning"}]}
+//
+// A non-zero exit code is treated as a plugin failure.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+	"gopkg.in/yaml.v2"
+)
+
+// manifestFileName is the name of the manifest file inside a plugin directory.
+const manifestFileName = "plugin.yaml"
+
+// Manifest describes a plugin's identity and entrypoint, as read from a
+// plugin directory's plugin.yaml.
+type Manifest struct {
+	Name        string `yaml:"name"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description"`
+	// Entrypoint is the executable to run, relative to the plugin's
+	// directory (e.g. "bin/run.sh" or "my-plugin").
+	Entrypoint string `yaml:"entrypoint"`
+}
+
+// Plugin is a discovered plugin: its manifest plus the directory it was
+// found in, which Entrypoint is resolved against.
+type Plugin struct {
+	Manifest
+	Dir string
+}
+
+// EntrypointPath returns the plugin's entrypoint joined onto its Dir; the
+// result is absolute only when Dir is.
+func (p *Plugin) EntrypointPath() string {
+	return filepath.Join(p.Dir, p.Entrypoint)
+}
+
+// envelope is the JSON document exchanged with a plugin over stdin/stdout.
+type envelope struct {
+	Type   string                       `json:"type,omitempty"`
+	Schema *jsonschema.Schema           `json:"schema,omitempty"`
+	Issues []jsonschema.ValidationIssue `json:"issues,omitempty"`
+}
+
+// Result is the outcome of running a single plugin: Schema is set for a
+// transform plugin (type "transform"), Issues for a validator plugin (type
+// "validator"), where it may be empty. Run copies both fields from the
+// plugin's envelope whatever its type; RunAll reads only the one that
+// matches Type.
+type Result struct {
+	Type   string
+	Schema *jsonschema.Schema
+	Issues []jsonschema.ValidationIssue
+}
+
+// FindPlugins scans dir for immediate subdirectories containing a
+// plugin.yaml manifest, returning one Plugin per manifest found. A dir that
+// doesn't exist yields no plugins and no error, since an unconfigured or
+// never-populated plugins directory is the common case.
+func FindPlugins(dir string) ([]*Plugin, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugins directory: %w", err)
+	}
+
+	var plugins []*Plugin
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pluginDir := filepath.Join(dir, entry.Name())
+		manifest, err := loadManifest(pluginDir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load plugin manifest in %s: %w", pluginDir, err)
+		}
+		plugins = append(plugins, &Plugin{Manifest: *manifest, Dir: pluginDir})
+	}
+
+	return plugins, nil
+}
+
+// loadManifest reads and parses the plugin.yaml in pluginDir.
+func loadManifest(pluginDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(pluginDir, manifestFileName)) // #nosec G304 -- pluginDir comes from a directory scan, not user input
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", manifestFileName, err)
+	}
+	if manifest.Name == "" {
+		return nil, fmt.Errorf("%s is missing a name", manifestFileName)
+	}
+	if manifest.Entrypoint == "" {
+		return nil, fmt.Errorf("%s is missing an entrypoint", manifestFileName)
+	}
+
+	return &manifest, nil
+}
+
+// Run invokes p's entrypoint with schema encoded as {"schema": ...} on
+// stdin, and decodes its stdout envelope into a Result. A non-zero exit
+// code, or output that isn't a recognized envelope, is returned as an error.
+func (p *Plugin) Run(ctx context.Context, schema *jsonschema.Schema) (*Result, error) {
+	input, err := json.Marshal(envelope{Schema: schema})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode schema for plugin %q: %w", p.Name, err)
+	}
+
+	cmd := exec.CommandContext(ctx, p.EntrypointPath()) // #nosec G204 -- entrypoint comes from a locally installed plugin manifest, not user input
+	cmd.Dir = p.Dir
+	cmd.Stdin = bytes.NewReader(input)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("plugin %q failed: %w: %s", p.Name, err, stderr.String())
+	}
+
+	var out envelope
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, fmt.Errorf("plugin %q returned a malformed envelope: %w", p.Name, err)
+	}
+
+	switch out.Type {
+	case "transform":
+		if out.Schema == nil {
+			return nil, fmt.Errorf("plugin %q is a transform plugin but returned no schema", p.Name)
+		}
+	case "validator":
+		// Issues may legitimately be empty.
+	default:
+		return nil, fmt.Errorf("plugin %q returned unknown envelope type %q", p.Name, out.Type)
+	}
+
+	return &Result{Type: out.Type, Schema: out.Schema, Issues: out.Issues}, nil
+}
+
+// RunAll runs every plugin in plugins against schema in order. Transform
+// plugins feed their output into the next plugin; the final schema and the
+// combined list of validator issues are returned. A plugin failure aborts
+// the remaining plugins and is returned as an error.
+func RunAll(ctx context.Context, plugins []*Plugin, schema *jsonschema.Schema) (*jsonschema.Schema, []jsonschema.ValidationIssue, error) {
+	var issues []jsonschema.ValidationIssue
+
+	for _, p := range plugins {
+		result, err := p.Run(ctx, schema)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch result.Type {
+		case "transform":
+			schema = result.Schema
+		case "validator":
+			issues = append(issues, result.Issues...)
+		}
+	}
+
+	return schema, issues, nil
+}
@@ -0,0 +1,132 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+// writePlugin creates a plugin directory under dir/name containing a
+// plugin.yaml and a shell script entrypoint running script.
+func writePlugin(t *testing.T, dir, name, script string) string {
+	t.Helper()
+
+	pluginDir := filepath.Join(dir, name)
+	if err := os.MkdirAll(pluginDir, 0750); err != nil {
+		t.Fatalf("failed to create plugin dir: %v", err)
+	}
+
+	manifest := "name: " + name + "\nversion: 1.0.0\ndescription: test plugin\nentrypoint: run.sh\n"
+	if err := os.WriteFile(filepath.Join(pluginDir, manifestFileName), []byte(manifest), 0600); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(pluginDir, "run.sh"), []byte("#!/bin/sh\n"+script+"\n"), 0700); err != nil {
+		t.Fatalf("failed to write entrypoint: %v", err)
+	}
+
+	return pluginDir
+}
+
+func TestFindPlugins_MissingDirYieldsNoPlugins(t *testing.T) {
+	plugins, err := FindPlugins(filepath.Join(t.TempDir(), "does-not-exist"))
+	if err != nil {
+		t.Fatalf("expected no error for a missing plugins directory, got %v", err)
+	}
+	if len(plugins) != 0 {
+		t.Errorf("expected no plugins, got %d", len(plugins))
+	}
+}
+
+func TestFindPlugins_DiscoversManifests(t *testing.T) {
+	dir := t.TempDir()
+	writePlugin(t, dir, "my-plugin", "cat")
+
+	plugins, err := FindPlugins(dir)
+	if err != nil {
+		t.Fatalf("FindPlugins failed: %v", err)
+	}
+	if len(plugins) != 1 {
+		t.Fatalf("expected 1 plugin, got %d", len(plugins))
+	}
+	if plugins[0].Name != "my-plugin" || plugins[0].Version != "1.0.0" {
+		t.Errorf("unexpected manifest fields: %+v", plugins[0].Manifest)
+	}
+}
+
+func TestPluginRun_TransformPluginReplacesSchema(t *testing.T) {
+	dir := t.TempDir()
+	pluginDir := writePlugin(t, dir, "transformer", `echo '{"type":"transform","schema":{"title":"from plugin"}}'`)
+
+	plugins, err := FindPlugins(dir)
+	if err != nil || len(plugins) != 1 {
+		t.Fatalf("FindPlugins failed: %v, %d plugins", err, len(plugins))
+	}
+	if plugins[0].Dir != pluginDir {
+		t.Fatalf("expected plugin dir %s, got %s", pluginDir, plugins[0].Dir)
+	}
+
+	result, err := plugins[0].Run(context.Background(), &jsonschema.Schema{Title: "original"})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Type != "transform" || result.Schema == nil || result.Schema.Title != "from plugin" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestPluginRun_ValidatorPluginReturnsIssues(t *testing.T) {
+	dir := t.TempDir()
+	writePlugin(t, dir, "validator", `echo '{"type":"validator","issues":[{"path":"image.tag","message":"must not be latest","level":"error"}]}'`)
+
+	plugins, err := FindPlugins(dir)
+	if err != nil || len(plugins) != 1 {
+		t.Fatalf("FindPlugins failed: %v, %d plugins", err, len(plugins))
+	}
+
+	result, err := plugins[0].Run(context.Background(), &jsonschema.Schema{})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Path != "image.tag" {
+		t.Errorf("unexpected issues: %+v", result.Issues)
+	}
+}
+
+func TestPluginRun_NonZeroExitIsAnError(t *testing.T) {
+	dir := t.TempDir()
+	writePlugin(t, dir, "broken", `echo "boom" >&2; exit 1`)
+
+	plugins, err := FindPlugins(dir)
+	if err != nil || len(plugins) != 1 {
+		t.Fatalf("FindPlugins failed: %v, %d plugins", err, len(plugins))
+	}
+
+	if _, err := plugins[0].Run(context.Background(), &jsonschema.Schema{}); err == nil {
+		t.Error("expected an error from a plugin that exits non-zero")
+	}
+}
+
+func TestRunAll_ChainsTransformsAndCollectsIssues(t *testing.T) {
+	dir := t.TempDir()
+	writePlugin(t, dir, "a-transform", `echo '{"type":"transform","schema":{"title":"from a"}}'`)
+	writePlugin(t, dir, "b-validator", `echo '{"type":"validator","issues":[{"path":"x","message":"y","level":"info"}]}'`)
+
+	plugins, err := FindPlugins(dir)
+	if err != nil || len(plugins) != 2 {
+		t.Fatalf("FindPlugins failed: %v, %d plugins", err, len(plugins))
+	}
+
+	schema, issues, err := RunAll(context.Background(), plugins, &jsonschema.Schema{Title: "original"})
+	if err != nil {
+		t.Fatalf("RunAll failed: %v", err)
+	}
+	if schema.Title != "from a" {
+		t.Errorf("expected the transform plugin's schema to win, got title %q", schema.Title)
+	}
+	if len(issues) != 1 || issues[0].Path != "x" {
+		t.Errorf("expected the validator plugin's issue to be collected, got %+v", issues)
+	}
+}
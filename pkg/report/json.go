@@ -0,0 +1,38 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+// jsonChartResult is ChartResult's JSON shape: Err is rendered as a plain
+// string (or omitted) since error values don't marshal usefully on their
+// own, and Issues defaults to an empty array rather than null.
+type jsonChartResult struct {
+	Path   string                       `json:"path"`
+	Issues []jsonschema.ValidationIssue `json:"issues"`
+	Error  string                       `json:"error,omitempty"`
+}
+
+// writeJSON renders results as an indented JSON array, the machine-readable
+// counterpart to writeTable.
+func writeJSON(w io.Writer, results []ChartResult) error {
+	encoded := make([]jsonChartResult, 0, len(results))
+	for _, result := range results {
+		issues := result.Issues
+		if issues == nil {
+			issues = []jsonschema.ValidationIssue{}
+		}
+		entry := jsonChartResult{Path: result.Path, Issues: issues}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+		}
+		encoded = append(encoded, entry)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(encoded)
+}
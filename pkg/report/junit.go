@@ -0,0 +1,87 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+// junitTestSuites is the root element CI platforms (Jenkins, GitLab,
+// GitHub Actions' test-reporting actions) expect from a JUnit XML report:
+// one testsuite per chart, one testcase per best-practices issue found -
+// Error-level issues fail the testcase, Warning/Info are recorded as
+// skipped so they're visible without failing the build.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+	Skipped *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// writeJUnit renders results as JUnit XML.
+func writeJUnit(w io.Writer, results []ChartResult) error {
+	doc := junitTestSuites{}
+
+	for _, result := range results {
+		suite := junitTestSuite{Name: result.Path}
+
+		if result.Err != nil {
+			suite.Tests = 1
+			suite.Failures = 1
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:    "schema generation",
+				Failure: &junitFailure{Message: result.Err.Error()},
+			})
+			doc.Suites = append(doc.Suites, suite)
+			continue
+		}
+
+		if len(result.Issues) == 0 {
+			suite.Tests = 1
+			suite.Cases = append(suite.Cases, junitTestCase{Name: "best practices"})
+			doc.Suites = append(doc.Suites, suite)
+			continue
+		}
+
+		for i, issue := range result.Issues {
+			suite.Tests++
+			testCase := junitTestCase{Name: fmt.Sprintf("best practices #%d: %s", i+1, issue.Path)}
+			if issue.Level == jsonschema.Error {
+				suite.Failures++
+				testCase.Failure = &junitFailure{Message: issue.Message}
+			} else {
+				testCase.Skipped = &junitSkipped{Message: issue.Message}
+			}
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
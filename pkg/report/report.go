@@ -0,0 +1,59 @@
+// Package report renders the aggregated outcome of a batch
+// "helm-schema-gen" run - one ChartResult per chart processed - as a
+// human-readable table or as JSON/SARIF/JUnit for CI consumption. SARIF in
+// particular lets platforms like GitHub Code Scanning surface Helm
+// best-practice violations inline on a pull request.
+package report
+
+import (
+	"fmt"
+	"io"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+// ChartResult is one chart's outcome from a batch generate run: the chart's
+// values.yaml path, the best-practices (and plugin) issues found, and the
+// error, if any, generation failed with. A non-nil Err means Issues wasn't
+// computed for this chart.
+type ChartResult struct {
+	Path   string
+	Issues []jsonschema.ValidationIssue
+	Err    error
+}
+
+// Format identifies one of the supported report encodings.
+type Format string
+
+const (
+	Table Format = "table"
+	JSON  Format = "json"
+	SARIF Format = "sarif"
+	JUnit Format = "junit"
+)
+
+// ParseFormat validates a --report-format flag value.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case Table, JSON, SARIF, JUnit:
+		return Format(s), nil
+	default:
+		return "", fmt.Errorf("unknown report format %q: must be one of table, json, sarif, junit", s)
+	}
+}
+
+// Write renders results in format to w.
+func Write(w io.Writer, format Format, results []ChartResult) error {
+	switch format {
+	case Table:
+		return writeTable(w, results)
+	case JSON:
+		return writeJSON(w, results)
+	case SARIF:
+		return writeSARIF(w, results)
+	case JUnit:
+		return writeJUnit(w, results)
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+}
@@ -0,0 +1,119 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+func sampleResults() []ChartResult {
+	return []ChartResult{
+		{
+			Path: "charts/api/values.yaml",
+			Issues: []jsonschema.ValidationIssue{
+				{Path: "image.tag", Message: "tag should not be 'latest'", Level: jsonschema.Warning, Position: jsonschema.Position{Line: 4, Column: 2}},
+			},
+		},
+		{Path: "charts/broken/values.yaml", Err: errors.New("failed to unmarshal YAML")},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, valid := range []string{"table", "json", "sarif", "junit"} {
+		if _, err := ParseFormat(valid); err != nil {
+			t.Errorf("ParseFormat(%q) returned an error: %v", valid, err)
+		}
+	}
+	if _, err := ParseFormat("yaml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Table, sampleResults()); err != nil {
+		t.Fatalf("Write returned an error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "image.tag") || !strings.Contains(out, "failed to unmarshal YAML") {
+		t.Errorf("expected table output to mention the issue and the error, got:\n%s", out)
+	}
+	if !strings.Contains(out, "2 chart(s)") {
+		t.Errorf("expected a summary line, got:\n%s", out)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, JSON, sampleResults()); err != nil {
+		t.Fatalf("Write returned an error: %v", err)
+	}
+
+	var decoded []jsonChartResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("failed to decode JSON report: %v", err)
+	}
+	if len(decoded) != 2 {
+		t.Fatalf("expected 2 chart results, got %d", len(decoded))
+	}
+	if decoded[0].Issues[0].Message != "tag should not be 'latest'" {
+		t.Errorf("unexpected first issue message: %+v", decoded[0])
+	}
+	if decoded[1].Error == "" {
+		t.Errorf("expected the second chart's error to be populated, got %+v", decoded[1])
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, SARIF, sampleResults()); err != nil {
+		t.Fatalf("Write returned an error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("failed to decode SARIF log: %v", err)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("expected exactly one run, got %d", len(log.Runs))
+	}
+	if len(log.Runs[0].Results) != 2 {
+		t.Fatalf("expected 2 results (1 issue + 1 failed chart), got %d", len(log.Runs[0].Results))
+	}
+
+	warning := log.Runs[0].Results[0]
+	if warning.Level != "warning" {
+		t.Errorf("expected warning level, got %q", warning.Level)
+	}
+	if warning.Locations[0].PhysicalLocation.Region.StartLine != 5 {
+		t.Errorf("expected 1-based startLine 5 (from 0-based Position.Line 4), got %d", warning.Locations[0].PhysicalLocation.Region.StartLine)
+	}
+
+	failure := log.Runs[0].Results[1]
+	if failure.Level != "error" {
+		t.Errorf("expected error level for the failed chart, got %q", failure.Level)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, JUnit, sampleResults()); err != nil {
+		t.Fatalf("Write returned an error: %v", err)
+	}
+
+	var doc junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("failed to decode JUnit XML: %v", err)
+	}
+	if len(doc.Suites) != 2 {
+		t.Fatalf("expected 2 test suites, got %d", len(doc.Suites))
+	}
+	if doc.Suites[1].Failures != 1 {
+		t.Errorf("expected the broken chart's suite to report 1 failure, got %d", doc.Suites[1].Failures)
+	}
+}
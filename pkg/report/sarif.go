@@ -0,0 +1,121 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	jsonschema "github.com/dc-tec/helm-schema-gen/pkg/schema-generator"
+)
+
+// sarifVersion is the SARIF spec version this package emits.
+const sarifVersion = "2.1.0"
+
+// sarifSchema is the $schema URI GitHub Code Scanning (and most other SARIF
+// consumers) expect on a 2.1.0 log.
+const sarifSchema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+// writeSARIF renders results as a single SARIF 2.1.0 run, one result per
+// ValidationIssue (plus one for a chart that failed to generate), so CI
+// platforms like GitHub Code Scanning can surface best-practice violations
+// inline on a pull request.
+func writeSARIF(w io.Writer, results []ChartResult) error {
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: "helm-schema-gen"}}}
+
+	for _, result := range results {
+		if result.Err != nil {
+			run.Results = append(run.Results, sarifResult{
+				RuleID:  "generation-failed",
+				Level:   "error",
+				Message: sarifMessage{Text: result.Err.Error()},
+				Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: result.Path},
+				}}},
+			})
+			continue
+		}
+
+		for _, issue := range result.Issues {
+			var region *sarifRegion
+			if issue.Position.Line > 0 || issue.Position.Column > 0 {
+				// SARIF lines/columns are 1-based; jsonschema.Position is 0-based.
+				region = &sarifRegion{StartLine: issue.Position.Line + 1, StartColumn: issue.Position.Column + 1}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:  "helm-best-practices",
+				Level:   sarifLevel(issue.Level),
+				Message: sarifMessage{Text: issue.Message},
+				Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: result.Path},
+					Region:           region,
+				}}},
+			})
+		}
+	}
+
+	log := sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// sarifLevel maps a jsonschema.ValidationLevel to SARIF's result.level enum
+// ("error", "warning", or "note").
+func sarifLevel(level jsonschema.ValidationLevel) string {
+	switch level {
+	case jsonschema.Error:
+		return "error"
+	case jsonschema.Warning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,42 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// writeTable renders results as an aligned, human-readable table, one row
+// per issue (or one row per chart for a chart that failed to generate),
+// followed by a one-line summary count.
+func writeTable(w io.Writer, results []ChartResult) error {
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHART\tLEVEL\tPATH\tMESSAGE")
+
+	var errorCount, warningCount, infoCount int
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(tw, "%s\tERROR\t\t%s\n", result.Path, result.Err)
+			errorCount++
+			continue
+		}
+		for _, issue := range result.Issues {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", result.Path, issue.Level, issue.Path, issue.Message)
+			switch issue.Level {
+			case "error":
+				errorCount++
+			case "warning":
+				warningCount++
+			default:
+				infoCount++
+			}
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d chart(s): %d error(s), %d warning(s), %d info\n",
+		len(results), errorCount, warningCount, infoCount)
+	return err
+}
@@ -0,0 +1,134 @@
+package jsonschema
+
+import (
+	"context"
+	"fmt"
+)
+
+// inferArrayItems infers the Items schema for a non-empty array whose
+// elements aren't already handled by tuple or mixed-scalar-type inference,
+// honoring Options.ArrayInference.
+func (g *Generator) inferArrayItems(ctx context.Context, elements []any, path string) (*Schema, error) {
+	mode := g.Options.ArrayInference
+	if mode == "" {
+		mode = ArrayInferenceMergeAll
+	}
+
+	if mode == ArrayInferenceFirstOnly {
+		return g.inferSchema(ctx, elements[0], fmt.Sprintf("%s[0]", path))
+	}
+
+	var merged *Schema
+	var firstHash string
+	for i, element := range elements {
+		itemSchema, err := g.inferSchema(ctx, element, fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to infer schema for array item %d: %w", i, err)
+		}
+
+		if mode == ArrayInferenceStrict {
+			hash := canonicalHash(itemSchema)
+			if i == 0 {
+				firstHash = hash
+			} else if hash != firstHash {
+				return nil, fmt.Errorf("array elements at '%s' have inconsistent shapes under strict array inference", path)
+			}
+		}
+
+		merged = mergeSchemas(merged, itemSchema)
+	}
+
+	merged.HelmPath = fmt.Sprintf("%s[0]", path)
+	return merged, nil
+}
+
+// mergeSchemas folds b into a, producing a schema describing any value valid
+// under either input: Type is unioned into a multi-type array when they
+// differ, Enum values are unioned, object Properties are the union of both
+// sides (recursively merged where a key appears on both), Required is their
+// intersection (a key stays required only if both sides require it), Items
+// are merged recursively, and Format is kept only when both sides agree.
+// Either argument may be nil, in which case the other is returned unchanged.
+func mergeSchemas(a, b *Schema) *Schema {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	merged := &Schema{
+		HelmPath: a.HelmPath,
+		Type:     unionSchemaTypes(a.Type, schemaTypes(b.Type)),
+		Enum:     unionEnumValues(a.Enum, b.Enum),
+		Required: intersectRequired(a.Required, b.Required),
+	}
+
+	if a.Format != "" && a.Format == b.Format {
+		merged.Format = a.Format
+	}
+
+	if len(a.Properties) > 0 || len(b.Properties) > 0 {
+		merged.Properties = make(map[string]*Schema, len(a.Properties)+len(b.Properties))
+		for key, propA := range a.Properties {
+			if propB, ok := b.Properties[key]; ok {
+				merged.Properties[key] = mergeSchemas(propA, propB)
+			} else {
+				merged.Properties[key] = propA
+			}
+		}
+		for key, propB := range b.Properties {
+			if _, ok := a.Properties[key]; !ok {
+				merged.Properties[key] = propB
+			}
+		}
+	}
+
+	if a.Items != nil || b.Items != nil {
+		merged.Items = mergeSchemas(a.Items, b.Items)
+	}
+
+	return merged
+}
+
+// unionEnumValues combines two Enum slices, keeping first-seen order and
+// dropping duplicates.
+func unionEnumValues(a, b []any) []any {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+
+	seen := make(map[any]bool, len(a)+len(b))
+	var union []any
+	for _, values := range [][]any{a, b} {
+		for _, v := range values {
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+			union = append(union, v)
+		}
+	}
+	return union
+}
+
+// intersectRequired returns the Required entries common to both a and b, so
+// a key stays required only when every merged element requires it.
+func intersectRequired(a, b []string) []string {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+
+	inB := make(map[string]bool, len(b))
+	for _, key := range b {
+		inB[key] = true
+	}
+
+	var common []string
+	for _, key := range a {
+		if inB[key] {
+			common = append(common, key)
+		}
+	}
+	return common
+}
@@ -0,0 +1,154 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGenerateFromMap_ArrayMergeAllUnifiesHeterogeneousObjectElements(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+	data := map[string]any{
+		"hosts": []any{
+			map[string]any{"host": "example.com", "paths": []any{"/"}},
+			map[string]any{"host": "other.example.com", "tls": true},
+		},
+	}
+
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	items := schema.Properties["hosts"].Items
+	if items == nil {
+		t.Fatal("expected an items schema for hosts")
+	}
+
+	for _, key := range []string{"host", "paths", "tls"} {
+		if _, ok := items.Properties[key]; !ok {
+			t.Errorf("expected merged items schema to carry property %q, got %+v", key, items.Properties)
+		}
+	}
+
+	for _, key := range items.Required {
+		if key != "host" {
+			continue
+		}
+		t.Errorf("expected 'host' to drop out of Required since not every element set it consistently as required, got %+v", items.Required)
+	}
+}
+
+func TestGenerateFromMap_ArrayFirstOnlyKeepsLegacyBehavior(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:  Draft07,
+		ArrayInference: ArrayInferenceFirstOnly,
+	})
+
+	data := map[string]any{
+		"hosts": []any{
+			map[string]any{"host": "example.com"},
+			map[string]any{"tls": true},
+		},
+	}
+
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	items := schema.Properties["hosts"].Items
+	if _, ok := items.Properties["host"]; !ok {
+		t.Error("expected items schema to come from the first element only")
+	}
+	if _, ok := items.Properties["tls"]; ok {
+		t.Error("expected ArrayInferenceFirstOnly to ignore the second element's properties")
+	}
+}
+
+func TestGenerateFromMap_ArrayStrictErrorsOnInconsistentShapes(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:  Draft07,
+		ArrayInference: ArrayInferenceStrict,
+	})
+
+	data := map[string]any{
+		"hosts": []any{
+			map[string]any{"host": "example.com"},
+			map[string]any{"host": "other.example.com", "tls": true},
+		},
+	}
+
+	if _, err := generator.GenerateFromMap(ctx, data); err == nil {
+		t.Error("expected an error for inconsistently-shaped array elements under ArrayInferenceStrict")
+	}
+}
+
+func TestMergeSchemas_UnionsTypesEnumsAndIntersectsRequired(t *testing.T) {
+	a := &Schema{
+		Type:     TypeObject,
+		Required: []string{"name", "port"},
+		Properties: map[string]*Schema{
+			"name": {Type: TypeString, Enum: []any{"a", "b"}},
+			"port": {Type: TypeInteger},
+		},
+	}
+	b := &Schema{
+		Type:     TypeObject,
+		Required: []string{"name"},
+		Properties: map[string]*Schema{
+			"name":    {Type: TypeString, Enum: []any{"b", "c"}},
+			"enabled": {Type: TypeBoolean},
+		},
+	}
+
+	merged := mergeSchemas(a, b)
+
+	if len(merged.Required) != 1 || merged.Required[0] != "name" {
+		t.Errorf("expected only 'name' to remain required, got %+v", merged.Required)
+	}
+
+	name := merged.Properties["name"]
+	if len(name.Enum) != 3 {
+		t.Errorf("expected the union of both sides' enum values, got %+v", name.Enum)
+	}
+
+	if _, ok := merged.Properties["port"]; !ok {
+		t.Error("expected 'port' (only present in a) to survive in the union")
+	}
+	if _, ok := merged.Properties["enabled"]; !ok {
+		t.Error("expected 'enabled' (only present in b) to survive in the union")
+	}
+}
+
+func TestMergeSchemas_UnionsScalarTypesAndDropsDisagreeingFormat(t *testing.T) {
+	a := &Schema{Type: TypeString, Format: "date"}
+	b := &Schema{Type: TypeInteger, Format: "date-time"}
+
+	merged := mergeSchemas(a, b)
+
+	types, ok := merged.Type.([]SchemaType)
+	if !ok || len(types) != 2 {
+		t.Fatalf("expected a two-element type union, got %+v", merged.Type)
+	}
+	if merged.Format != "" {
+		t.Errorf("expected disagreeing Format values to be dropped, got %q", merged.Format)
+	}
+}
+
+func TestMergeSchemas_NilEitherSideReturnsTheOther(t *testing.T) {
+	schema := &Schema{Type: TypeString}
+
+	if mergeSchemas(nil, schema) != schema {
+		t.Error("expected mergeSchemas(nil, b) to return b unchanged")
+	}
+	if mergeSchemas(schema, nil) != schema {
+		t.Error("expected mergeSchemas(a, nil) to return a unchanged")
+	}
+}
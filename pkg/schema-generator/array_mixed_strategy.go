@@ -0,0 +1,72 @@
+package jsonschema
+
+import (
+	"context"
+	"fmt"
+)
+
+// inferMixedTypeArrayItems fills in schema's array-item representation for a
+// heterogeneous array (hasMixedTypes(items) is true), dispatching on
+// Options.ArrayMixedStrategy.
+func (g *Generator) inferMixedTypeArrayItems(ctx context.Context, schema *Schema, items []any, path string) error {
+	switch g.Options.ArrayMixedStrategy {
+	case ArrayMixedOneOf, ArrayMixedAnyOf:
+		branches, err := g.inferDedupedMixedBranches(ctx, items, path)
+		if err != nil {
+			return err
+		}
+		itemsSchema := &Schema{}
+		if g.Options.ArrayMixedStrategy == ArrayMixedAnyOf {
+			itemsSchema.AnyOf = branches
+		} else {
+			itemsSchema.OneOf = branches
+		}
+		schema.Items = itemsSchema
+
+	case ArrayMixedTupleValidation:
+		tupleItems := make([]*Schema, len(items))
+		for i, elem := range items {
+			itemSchema, err := g.inferSchema(ctx, elem, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return fmt.Errorf("failed to infer schema for tuple item %d: %w", i, err)
+			}
+			tupleItems[i] = itemSchema
+		}
+		g.assignTupleItems(schema, tupleItems)
+
+	default: // ArrayMixedUnion, or unset
+		mixedSchema, err := g.InferArrayItemsWithMultipleTypes(ctx, items, path)
+		if err != nil {
+			return err
+		}
+		schema.Items = &Schema{Type: mixedSchema.Type}
+	}
+
+	return nil
+}
+
+// inferDedupedMixedBranches infers a subschema per element of items,
+// deduplicating structurally identical shapes by canonicalHash, and returns
+// them as the []any a Schema.OneOf/AnyOf expects. The first occurrence of
+// each distinct shape determines its position, so output order is
+// deterministic across runs given the same input.
+func (g *Generator) inferDedupedMixedBranches(ctx context.Context, items []any, path string) ([]any, error) {
+	seen := make(map[string]bool, len(items))
+	branches := make([]any, 0, len(items))
+
+	for i, item := range items {
+		itemSchema, err := g.inferSchema(ctx, item, fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to infer schema for array item %d: %w", i, err)
+		}
+
+		hash := canonicalHash(itemSchema)
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+		branches = append(branches, itemSchema)
+	}
+
+	return branches, nil
+}
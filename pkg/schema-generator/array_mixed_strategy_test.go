@@ -0,0 +1,145 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestArrayMixedStrategy_Union(t *testing.T) {
+	ctx := context.Background()
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+	data := map[string]any{"mixed": []any{1, "s", true, 3.14}}
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	items := schema.Properties["mixed"].Items
+	if items == nil {
+		t.Fatal("expected an Items schema")
+	}
+	if len(items.OneOf) != 0 || len(items.AnyOf) != 0 {
+		t.Errorf("expected the union strategy to leave OneOf/AnyOf unset, got %+v", items)
+	}
+	types, ok := items.Type.([]SchemaType)
+	if !ok || len(types) == 0 {
+		t.Errorf("expected Items.Type to be a union of the observed types, got %#v", items.Type)
+	}
+}
+
+func TestArrayMixedStrategy_OneOf(t *testing.T) {
+	ctx := context.Background()
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:      Draft07,
+		ArrayMixedStrategy: ArrayMixedOneOf,
+	})
+
+	data := map[string]any{"mixed": []any{1, "s", true, 3.14}}
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	items := schema.Properties["mixed"].Items
+	if items == nil {
+		t.Fatal("expected an Items schema")
+	}
+	if len(items.AnyOf) != 0 {
+		t.Errorf("expected AnyOf to stay empty, got %+v", items.AnyOf)
+	}
+	// 1 and 3.14 infer to distinct types (integer vs number), so along with
+	// string and boolean every element produces its own branch.
+	if len(items.OneOf) != 4 {
+		t.Fatalf("expected 4 deduplicated branches (integer, string, boolean, number), got %d: %+v", len(items.OneOf), items.OneOf)
+	}
+}
+
+func TestArrayMixedStrategy_AnyOf(t *testing.T) {
+	ctx := context.Background()
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:      Draft07,
+		ArrayMixedStrategy: ArrayMixedAnyOf,
+	})
+
+	data := map[string]any{"mixed": []any{1, "s", true, 3.14}}
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	items := schema.Properties["mixed"].Items
+	if items == nil {
+		t.Fatal("expected an Items schema")
+	}
+	if len(items.OneOf) != 0 {
+		t.Errorf("expected OneOf to stay empty, got %+v", items.OneOf)
+	}
+	if len(items.AnyOf) != 4 {
+		t.Fatalf("expected 4 deduplicated branches, got %d: %+v", len(items.AnyOf), items.AnyOf)
+	}
+}
+
+func TestArrayMixedStrategy_TupleValidation(t *testing.T) {
+	ctx := context.Background()
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:      Draft07,
+		ArrayMixedStrategy: ArrayMixedTupleValidation,
+	})
+
+	data := map[string]any{"mixed": []any{1, "s", true, 3.14}}
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	mixed := schema.Properties["mixed"]
+	if mixed.Items != nil {
+		t.Errorf("expected tuple validation to leave Items unset, got %+v", mixed.Items)
+	}
+	if len(mixed.TupleLegacyItems) != 4 {
+		t.Fatalf("expected 4 positional item schemas on draft-07, got %d", len(mixed.TupleLegacyItems))
+	}
+	if mixed.TupleLegacyItems[0].Type != TypeInteger {
+		t.Errorf("expected the first positional item to be an integer, got %v", mixed.TupleLegacyItems[0].Type)
+	}
+	if mixed.TupleLegacyItems[1].Type != TypeString {
+		t.Errorf("expected the second positional item to be a string, got %v", mixed.TupleLegacyItems[1].Type)
+	}
+}
+
+func TestArrayMixedStrategy_OneOf_HeterogeneousObjectsWithOverlappingKeys(t *testing.T) {
+	ctx := context.Background()
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:      Draft07,
+		ArrayMixedStrategy: ArrayMixedOneOf,
+	})
+
+	// hasMixedTypes only flags an array once it spans more than one Go kind,
+	// so "legacy" (a plain string) is what makes this array mixed; once it
+	// is, inferDedupedMixedBranches still dedupes the two object elements
+	// that share a shape while keeping the structurally distinct one apart.
+	data := map[string]any{
+		"rules": []any{
+			map[string]any{"name": "a", "port": 80},
+			map[string]any{"name": "b", "port": 80},
+			map[string]any{"name": "c", "protocol": "TCP"},
+			"legacy",
+		},
+	}
+
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	items := schema.Properties["rules"].Items
+	if items == nil {
+		t.Fatal("expected an Items schema")
+	}
+	// Branches: the deduplicated {name, port} shape, the distinct
+	// {name, protocol} shape, and the string element.
+	if len(items.OneOf) != 3 {
+		t.Fatalf("expected 3 deduplicated branches for overlapping-but-distinct object shapes plus the string element, got %d", len(items.OneOf))
+	}
+}
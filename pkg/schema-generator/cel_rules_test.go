@@ -0,0 +1,115 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGenerateFromMap_EmitsResourceAndReplicaCountCELRules(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:      Draft07,
+		EmitCELValidations: true,
+	})
+
+	data := map[string]any{
+		"replicaCount": 1,
+		"resources": map[string]any{
+			"limits": map[string]any{
+				"cpu":    "500m",
+				"memory": "256Mi",
+			},
+			"requests": map[string]any{
+				"cpu":    "250m",
+				"memory": "128Mi",
+			},
+		},
+		"image": map[string]any{
+			"pullPolicy": "IfNotPresent",
+		},
+	}
+
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	resources := schema.Properties["resources"]
+	if len(resources.XKubernetesValidations) != 4 {
+		t.Fatalf("expected 4 CEL rules on the resources block, got %d: %+v", len(resources.XKubernetesValidations), resources.XKubernetesValidations)
+	}
+
+	replicaCount := schema.Properties["replicaCount"]
+	if len(replicaCount.XKubernetesValidations) != 1 || replicaCount.XKubernetesValidations[0].Rule != "self >= 0 && self <= 10000" {
+		t.Errorf("expected a replica count bound rule, got %+v", replicaCount.XKubernetesValidations)
+	}
+
+	pullPolicy := schema.Properties["image"].Properties["pullPolicy"]
+	if len(pullPolicy.XKubernetesValidations) != 1 || pullPolicy.XKubernetesValidations[0].Rule != "self in ['Always','IfNotPresent','Never']" {
+		t.Errorf("expected a pull policy enum rule, got %+v", pullPolicy.XKubernetesValidations)
+	}
+}
+
+func TestGenerateFromMap_NoCELRulesWhenDisabled(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+	data := map[string]any{"replicaCount": 1}
+
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	if len(schema.Properties["replicaCount"].XKubernetesValidations) != 0 {
+		t.Error("expected no CEL rules when EmitCELValidations is left unset")
+	}
+}
+
+func TestCELRuleBuilder_MutuallyExclusiveProperties(t *testing.T) {
+	schema := &Schema{
+		Type:     TypeObject,
+		HelmPath: "persistence",
+		Properties: map[string]*Schema{
+			"existingSecret": {Type: TypeString, HelmPath: "persistence.existingSecret"},
+			"secretName":     {Type: TypeString, HelmPath: "persistence.secretName"},
+		},
+	}
+
+	NewCELRuleBuilder(0).Apply(schema)
+
+	if len(schema.XKubernetesValidations) != 1 {
+		t.Fatalf("expected 1 mutually-exclusive rule, got %d: %+v", len(schema.XKubernetesValidations), schema.XKubernetesValidations)
+	}
+	if schema.XKubernetesValidations[0].Rule != "has(self.existingSecret) != has(self.secretName)" {
+		t.Errorf("unexpected rule: %s", schema.XKubernetesValidations[0].Rule)
+	}
+}
+
+func TestCELRuleBuilder_MaxCostDropsExpensiveRules(t *testing.T) {
+	schema := &Schema{
+		Type:     TypeObject,
+		HelmPath: "resources",
+		Properties: map[string]*Schema{
+			"limits":   {Type: TypeObject},
+			"requests": {Type: TypeObject},
+		},
+	}
+
+	NewCELRuleBuilder(1).Apply(schema)
+
+	if len(schema.XKubernetesValidations) != 0 {
+		t.Errorf("expected the .matches() resource rules to be dropped under a cost cap of 1, got %+v", schema.XKubernetesValidations)
+	}
+}
+
+func TestEstimateRuleCost(t *testing.T) {
+	cheap := estimateRuleCost("self >= 0 && self <= 10000")
+	expensive := estimateRuleCost(`self.limits.cpu.matches('^[0-9]+m?$')`)
+
+	if cheap >= expensive {
+		t.Errorf("expected a .matches() rule to cost more than a pair of scalar comparisons, got cheap=%d expensive=%d", cheap, expensive)
+	}
+}
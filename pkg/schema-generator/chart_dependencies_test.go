@@ -0,0 +1,177 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGenerateFromChart_ComposesSubchartDependencyByName(t *testing.T) {
+	ctx := context.Background()
+	chartDir := t.TempDir()
+
+	writeChartFile(t, chartDir, "values.yaml", "replicaCount: 1\n")
+	writeChartFile(t, chartDir, "Chart.yaml", "dependencies:\n  - name: redis\n")
+	writeChartFile(t, chartDir, "charts/redis/values.yaml", "auth:\n  enabled: true\n")
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+	schema, err := generator.GenerateFromChart(ctx, chartDir)
+	if err != nil {
+		t.Fatalf("GenerateFromChart failed: %v", err)
+	}
+
+	redis := schema.Properties["redis"]
+	if redis == nil {
+		t.Fatalf("expected a redis property nested for the subchart dependency")
+	}
+	if _, ok := redis.Properties["auth"]; !ok {
+		t.Errorf("expected the redis subchart's own values to be inferred, got %+v", redis.Properties)
+	}
+}
+
+func TestGenerateFromChart_AliasOverridesDependencyKey(t *testing.T) {
+	ctx := context.Background()
+	chartDir := t.TempDir()
+
+	writeChartFile(t, chartDir, "values.yaml", "replicaCount: 1\n")
+	writeChartFile(t, chartDir, "Chart.yaml", "dependencies:\n  - name: redis\n    alias: cache\n")
+	writeChartFile(t, chartDir, "charts/redis/values.yaml", "enabled: true\n")
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+	schema, err := generator.GenerateFromChart(ctx, chartDir)
+	if err != nil {
+		t.Fatalf("GenerateFromChart failed: %v", err)
+	}
+
+	if schema.Properties["redis"] != nil {
+		t.Error("expected the dependency to be nested under its alias, not its name")
+	}
+	if schema.Properties["cache"] == nil {
+		t.Fatal("expected the dependency to be nested under its alias 'cache'")
+	}
+}
+
+func TestGenerateFromChart_ConditionAddsBooleanAtDottedPath(t *testing.T) {
+	ctx := context.Background()
+	chartDir := t.TempDir()
+
+	writeChartFile(t, chartDir, "values.yaml", "replicaCount: 1\n")
+	writeChartFile(t, chartDir, "Chart.yaml", "dependencies:\n  - name: postgresql\n    condition: postgresql.enabled\n")
+	writeChartFile(t, chartDir, "charts/postgresql/values.yaml", "auth:\n  username: admin\n")
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+	schema, err := generator.GenerateFromChart(ctx, chartDir)
+	if err != nil {
+		t.Fatalf("GenerateFromChart failed: %v", err)
+	}
+
+	postgresql := schema.Properties["postgresql"]
+	if postgresql == nil {
+		t.Fatalf("expected a postgresql property")
+	}
+	enabled := postgresql.Properties["enabled"]
+	if enabled == nil {
+		t.Fatalf("expected postgresql.enabled to exist from the condition field")
+	}
+	if enabled.Type != TypeBoolean {
+		t.Errorf("expected postgresql.enabled to be boolean, got %v", enabled.Type)
+	}
+	if enabled.Description == "" {
+		t.Error("expected a description referencing the condition path")
+	}
+}
+
+func TestGenerateFromChart_ImportValuesCopiesChildPropertyToParent(t *testing.T) {
+	ctx := context.Background()
+	chartDir := t.TempDir()
+
+	writeChartFile(t, chartDir, "values.yaml", "replicaCount: 1\n")
+	writeChartFile(t, chartDir, "Chart.yaml", "dependencies:\n  - name: common\n    import-values:\n      - sharedLabels\n")
+	writeChartFile(t, chartDir, "charts/common/values.yaml", "sharedLabels:\n  team: platform\n")
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+	schema, err := generator.GenerateFromChart(ctx, chartDir)
+	if err != nil {
+		t.Fatalf("GenerateFromChart failed: %v", err)
+	}
+
+	imported, ok := schema.Properties["sharedLabels"]
+	if !ok {
+		t.Fatalf("expected sharedLabels to be imported to the parent's top-level properties, got %+v", schema.Properties)
+	}
+	if imported.HelmPath != "sharedLabels" {
+		t.Errorf("expected the imported property's HelmPath to be preserved from the child, got %q", imported.HelmPath)
+	}
+}
+
+func TestGenerateFromChart_ImportValuesRenamesViaChildParentMap(t *testing.T) {
+	ctx := context.Background()
+	chartDir := t.TempDir()
+
+	writeChartFile(t, chartDir, "values.yaml", "replicaCount: 1\n")
+	writeChartFile(t, chartDir, "Chart.yaml", `dependencies:
+  - name: common
+    import-values:
+      - child: labels
+        parent: commonLabels
+`)
+	writeChartFile(t, chartDir, "charts/common/values.yaml", "labels:\n  team: platform\n")
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+	schema, err := generator.GenerateFromChart(ctx, chartDir)
+	if err != nil {
+		t.Fatalf("GenerateFromChart failed: %v", err)
+	}
+
+	if _, ok := schema.Properties["commonLabels"]; !ok {
+		t.Errorf("expected labels to be imported under the renamed parent key commonLabels, got %+v", schema.Properties)
+	}
+}
+
+func TestGenerateFromChart_PrefersOnDiskSchemaAndMergesParentDescriptions(t *testing.T) {
+	ctx := context.Background()
+	chartDir := t.TempDir()
+
+	writeChartFile(t, chartDir, "values.yaml", "redis:\n  # Overridden for our cluster size\n  enabled: true\n")
+	writeChartFile(t, chartDir, "Chart.yaml", "dependencies:\n  - name: redis\n")
+	writeChartFile(t, chartDir, "charts/redis/values.yaml", "enabled: true\n")
+	writeChartFile(t, chartDir, "charts/redis/values.schema.json", `{
+		"type": "object",
+		"properties": {
+			"enabled": {"type": "boolean"}
+		}
+	}`)
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07, ExtractDescriptions: true})
+	schema, err := generator.GenerateFromChart(ctx, chartDir)
+	if err != nil {
+		t.Fatalf("GenerateFromChart failed: %v", err)
+	}
+
+	redis := schema.Properties["redis"]
+	if redis == nil {
+		t.Fatalf("expected a redis property")
+	}
+	enabled := redis.Properties["enabled"]
+	if enabled == nil {
+		t.Fatalf("expected redis.enabled from the on-disk schema")
+	}
+	if enabled.Description != "Overridden for our cluster size" {
+		t.Errorf("expected the parent's comment to be merged into the on-disk schema's node, got %q", enabled.Description)
+	}
+}
+
+func TestGenerateFromChart_NoChartYAMLSkipsComposition(t *testing.T) {
+	ctx := context.Background()
+	chartDir := t.TempDir()
+
+	writeChartFile(t, chartDir, "values.yaml", "replicaCount: 1\n")
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+	schema, err := generator.GenerateFromChart(ctx, chartDir)
+	if err != nil {
+		t.Fatalf("GenerateFromChart failed: %v", err)
+	}
+	if schema.Properties["replicaCount"] == nil {
+		t.Errorf("expected values.yaml fields to still be inferred with no Chart.yaml")
+	}
+}
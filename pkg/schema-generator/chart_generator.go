@@ -0,0 +1,491 @@
+package jsonschema
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dc-tec/helm-schema-gen/pkg/logging"
+	"gopkg.in/yaml.v2"
+)
+
+// valuesRefPattern matches a .Values reference such as ".Values.image.tag".
+var valuesRefPattern = regexp.MustCompile(`\.Values((?:\.[A-Za-z0-9_]+)+)`)
+
+// requiredPattern matches a `required "message" .Values.x.y.z` guard.
+var requiredPattern = regexp.MustCompile(`required\s+"[^"]*"\s+\.Values((?:\.[A-Za-z0-9_]+)+)`)
+
+// templateCommentPattern matches a standalone Go template comment line, e.g.
+// `{{- /* This is the replica count */ -}}`.
+var templateCommentPattern = regexp.MustCompile(`^\{\{-?\s*/\*(.*)\*/\s*-?\}\}$`)
+
+// chartReference records a single .Values path usage discovered while
+// scanning a chart's templates.
+type chartReference struct {
+	path        []string
+	required    bool
+	description string
+}
+
+// chartYAML holds the subset of Chart.yaml GenerateFromChart cares about.
+type chartYAML struct {
+	Dependencies []chartDependency `yaml:"dependencies"`
+}
+
+// chartDependency is a single entry of Chart.yaml's dependencies list.
+type chartDependency struct {
+	Name string `yaml:"name"`
+
+	// Alias overrides Name as the key the dependency's schema is nested
+	// under in the parent's properties, mirroring Helm's own alias rule.
+	Alias string `yaml:"alias"`
+
+	// Condition is a dotted values path (e.g. "postgresql.enabled") that
+	// toggles whether this dependency is installed.
+	Condition string `yaml:"condition"`
+
+	// ImportValues lists child properties to copy up into the parent's
+	// properties, as either a plain name (copied under the same key at the
+	// parent's top level) or a {child, parent} map naming a dotted path on
+	// each side.
+	ImportValues []any `yaml:"import-values"`
+}
+
+// GenerateFromChart generates a JSON schema for a Helm chart by combining
+// values.yaml inference with a scan of templates/*.yaml and
+// templates/_helpers.tpl for .Values references. Paths guarded by a
+// `required "msg" .Values.x.y.z` call become Required on their parent
+// schema regardless of RequireByDefault; paths referenced in templates but
+// absent from values.yaml are added as placeholder properties with
+// "default": null, so the schema documents keys the chart actually expects.
+//
+// When chartDir has a Chart.yaml with a dependencies list, each dependency
+// is composed into the result too: its schema - generated recursively from
+// charts/<name>, or loaded from charts/<name>/values.schema.json when
+// present - is nested under properties.<alias-or-name>. A dependency's
+// condition becomes a boolean property at that dotted values path
+// (creating it if values.yaml didn't already have it), and its
+// import-values entries are copied into the parent's properties (at the top
+// level for a plain name, at the dotted parent path for a {child, parent}
+// map), preserving their HelmPath.
+func (g *Generator) GenerateFromChart(ctx context.Context, chartDir string) (*Schema, error) {
+	logger := logging.WithComponent(ctx, "json-schema-generator")
+	logger.InfoContext(ctx, "generating schema from chart", "chartDir", chartDir)
+
+	valuesPath := filepath.Join(chartDir, "values.yaml")
+	yamlData, err := os.ReadFile(valuesPath) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read values.yaml: %w", err)
+	}
+
+	schema, err := g.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate schema from values.yaml: %w", err)
+	}
+
+	refs, err := scanChartTemplates(ctx, chartDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan chart templates: %w", err)
+	}
+
+	for _, ref := range refs {
+		applyChartReference(schema, ref)
+	}
+
+	dependencies, err := loadChartDependencies(chartDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Chart.yaml: %w", err)
+	}
+
+	for _, dep := range dependencies {
+		if err := g.composeDependency(ctx, schema, chartDir, dep); err != nil {
+			return nil, fmt.Errorf("failed to compose dependency '%s': %w", dep.Name, err)
+		}
+	}
+
+	logger.InfoContext(ctx, "chart schema generation completed", "referencesFound", len(refs), "dependencies", len(dependencies))
+	return schema, nil
+}
+
+// loadChartDependencies reads chartDir/Chart.yaml and returns its
+// dependencies list, or nil if the chart has no Chart.yaml.
+func loadChartDependencies(chartDir string) ([]chartDependency, error) {
+	data, err := os.ReadFile(filepath.Join(chartDir, "Chart.yaml")) // #nosec G304
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var chart chartYAML
+	if err := yaml.Unmarshal(data, &chart); err != nil {
+		return nil, fmt.Errorf("invalid Chart.yaml: %w", err)
+	}
+	return chart.Dependencies, nil
+}
+
+// composeDependency nests dep's schema under parent.Properties at its
+// alias-or-name key, honoring its condition and import-values entries.
+func (g *Generator) composeDependency(ctx context.Context, parent *Schema, chartDir string, dep chartDependency) error {
+	key := dep.Alias
+	if key == "" {
+		key = dep.Name
+	}
+	if key == "" {
+		return nil
+	}
+
+	childDir := filepath.Join(chartDir, "charts", dep.Name)
+	schemaPath := filepath.Join(childDir, "values.schema.json")
+	loadedFromDisk := fileExists(schemaPath)
+
+	childSchema, err := g.loadOrGenerateChildSchema(ctx, childDir, schemaPath)
+	if err != nil {
+		return err
+	}
+	if childSchema == nil {
+		return nil
+	}
+
+	if parent.Properties == nil {
+		parent.Properties = make(map[string]*Schema)
+	}
+
+	// A schema loaded verbatim from disk doesn't carry the descriptions the
+	// parent's own comment extractor picked up for keys it overrides - fold
+	// those back in so a parent-side override stays documented.
+	if loadedFromDisk {
+		mergeDescriptionsFromParent(childSchema, parent.Properties[key])
+	}
+
+	childSchema.HelmPath = key
+	parent.Properties[key] = childSchema
+
+	applyDependencyCondition(parent, dep)
+
+	for _, entry := range dep.ImportValues {
+		applyImportValue(parent, childSchema, entry)
+	}
+
+	return nil
+}
+
+// loadOrGenerateChildSchema prefers a dependency's own values.schema.json
+// when present, falling back to recursively generating one from childDir.
+// It returns a nil schema (not an error) when the dependency has neither a
+// schema nor a values.yaml to infer one from.
+func (g *Generator) loadOrGenerateChildSchema(ctx context.Context, childDir, schemaPath string) (*Schema, error) {
+	if data, err := os.ReadFile(schemaPath); err == nil { // #nosec G304
+		var child Schema
+		if err := json.Unmarshal(data, &child); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", schemaPath, err)
+		}
+		return &child, nil
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", schemaPath, err)
+	}
+
+	if !fileExists(filepath.Join(childDir, "values.yaml")) {
+		return nil, nil
+	}
+
+	return g.GenerateFromChart(ctx, childDir)
+}
+
+// fileExists reports whether path exists and is readable as a regular stat
+// target (used for Chart.yaml's "prefer what's on disk" checks).
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// applyDependencyCondition ensures a boolean property exists at dep's
+// dotted Condition path within parent (creating intermediate objects as
+// needed, same as applyChartReference does for template references), and
+// documents it with a description naming the dependency.
+func applyDependencyCondition(parent *Schema, dep chartDependency) {
+	if dep.Condition == "" {
+		return
+	}
+	path := splitValuesPath(dep.Condition)
+	if len(path) == 0 {
+		return
+	}
+
+	node := parent
+	for _, key := range path[:len(path)-1] {
+		if node.Ref != "" {
+			return
+		}
+		node = ensureObjectProperty(node, key)
+	}
+	if node.Ref != "" {
+		return
+	}
+	if node.Properties == nil {
+		node.Properties = make(map[string]*Schema)
+	}
+
+	leafKey := path[len(path)-1]
+	leaf, exists := node.Properties[leafKey]
+	if !exists {
+		leaf = &Schema{
+			Type:     TypeBoolean,
+			HelmPath: strings.TrimPrefix(node.HelmPath+"."+leafKey, "."),
+		}
+		node.Properties[leafKey] = leaf
+	}
+
+	if leaf.Description == "" {
+		leaf.Description = fmt.Sprintf("Controls whether the %q dependency is installed (Chart.yaml dependencies[].condition: %s)", dep.Name, dep.Condition)
+	}
+}
+
+// mergeDescriptionsFromParent copies non-empty Description values from src
+// (the schema the generator already inferred for this path from the
+// parent's own values.yaml and its comments) onto the matching node of dst
+// (a dependency schema loaded verbatim from values.schema.json), by
+// property name.
+func mergeDescriptionsFromParent(dst, src *Schema) {
+	if dst == nil || src == nil {
+		return
+	}
+	if src.Description != "" {
+		dst.Description = src.Description
+	}
+	for key, prop := range dst.Properties {
+		if srcProp, ok := src.Properties[key]; ok {
+			mergeDescriptionsFromParent(prop, srcProp)
+		}
+	}
+}
+
+// applyImportValue copies one Chart.yaml import-values entry from child
+// into parent's properties. A plain string names a top-level
+// child property copied under the same key; a {child, parent} map names a
+// dotted path on each side, letting an import rename or nest the value.
+func applyImportValue(parent, child *Schema, entry any) {
+	switch v := entry.(type) {
+	case string:
+		copyImportedProperty(parent, child, []string{v}, []string{v})
+	case map[string]any:
+		applyImportValueMap(parent, child, v)
+	case map[any]any:
+		normalized := make(map[string]any, len(v))
+		for k, val := range v {
+			if ks, ok := k.(string); ok {
+				normalized[ks] = val
+			}
+		}
+		applyImportValueMap(parent, child, normalized)
+	}
+}
+
+// applyImportValueMap handles the {child, parent} map form of import-values.
+func applyImportValueMap(parent, child *Schema, entry map[string]any) {
+	childPath, _ := entry["child"].(string)
+	if childPath == "" {
+		return
+	}
+	parentPath, _ := entry["parent"].(string)
+	if parentPath == "" {
+		parentPath = childPath
+	}
+
+	copyImportedProperty(parent, child, splitValuesPath(childPath), splitValuesPath(parentPath))
+}
+
+// copyImportedProperty resolves childPath against child.Properties and, if
+// found, assigns the resulting node (unmodified, so its HelmPath is
+// preserved) at parentPath under parent.Properties, creating intermediate
+// objects as needed.
+func copyImportedProperty(parent, child *Schema, childPath, parentPath []string) {
+	node := child
+	for _, key := range childPath {
+		if node == nil || node.Properties == nil {
+			return
+		}
+		node = node.Properties[key]
+	}
+	if node == nil || len(parentPath) == 0 {
+		return
+	}
+
+	dest := parent
+	for _, key := range parentPath[:len(parentPath)-1] {
+		dest = ensureObjectProperty(dest, key)
+	}
+	if dest.Properties == nil {
+		dest.Properties = make(map[string]*Schema)
+	}
+	dest.Properties[parentPath[len(parentPath)-1]] = node
+}
+
+// scanChartTemplates walks templates/*.yaml, templates/*.yml and
+// templates/_helpers.tpl under chartDir, collecting every .Values reference
+// it finds. A chart with no templates directory yields no references.
+func scanChartTemplates(ctx context.Context, chartDir string) ([]chartReference, error) {
+	logger := logging.WithComponent(ctx, "json-schema-generator")
+	templatesDir := filepath.Join(chartDir, "templates")
+
+	entries, err := os.ReadDir(templatesDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+
+	var refs []chartReference
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") && name != "_helpers.tpl" {
+			continue
+		}
+
+		file := filepath.Join(templatesDir, name)
+		data, err := os.ReadFile(file) // #nosec G304
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to read template file", "file", file, "error", err)
+			return nil, fmt.Errorf("failed to read template file '%s': %w", file, err)
+		}
+		refs = append(refs, scanTemplateReferences(string(data))...)
+	}
+
+	return refs, nil
+}
+
+// scanTemplateReferences extracts .Values references from a single
+// template's contents, tracking the nearest preceding `{{- /* ... */ -}}`
+// or `#` comment as a candidate description for the referenced path.
+func scanTemplateReferences(contents string) []chartReference {
+	var refs []chartReference
+	var pendingComment string
+
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" {
+			continue
+		}
+
+		if m := templateCommentPattern.FindStringSubmatch(trimmed); m != nil {
+			pendingComment = strings.TrimSpace(m[1])
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			pendingComment = strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+			continue
+		}
+
+		requiredPaths := make(map[string]bool)
+		for _, m := range requiredPattern.FindAllStringSubmatch(line, -1) {
+			path := splitValuesPath(m[1])
+			refs = append(refs, chartReference{
+				path:        path,
+				required:    true,
+				description: pendingComment,
+			})
+			requiredPaths[m[1]] = true
+		}
+
+		for _, m := range valuesRefPattern.FindAllStringSubmatch(line, -1) {
+			if requiredPaths[m[1]] {
+				continue
+			}
+			refs = append(refs, chartReference{
+				path:        splitValuesPath(m[1]),
+				description: pendingComment,
+			})
+		}
+
+		pendingComment = ""
+	}
+
+	return refs
+}
+
+// splitValuesPath turns ".image.tag" into ["image", "tag"].
+func splitValuesPath(dotted string) []string {
+	return strings.Split(strings.TrimPrefix(dotted, "."), ".")
+}
+
+// applyChartReference folds a single chart reference into schema, creating
+// placeholder object/leaf properties for any path segment values.yaml
+// didn't already provide, and marking the leaf required on its parent when
+// the reference came from a `required` guard.
+func applyChartReference(schema *Schema, ref chartReference) {
+	if len(ref.path) == 0 {
+		return
+	}
+
+	node := schema
+	for _, key := range ref.path[:len(ref.path)-1] {
+		if node.Ref != "" {
+			// Already hoisted into a shared $defs/definitions entry; leave
+			// it alone rather than risk mutating a schema other paths share.
+			return
+		}
+		node = ensureObjectProperty(node, key)
+	}
+
+	if node.Ref != "" {
+		return
+	}
+	if node.Properties == nil {
+		node.Properties = make(map[string]*Schema)
+	}
+
+	leafKey := ref.path[len(ref.path)-1]
+	leaf, exists := node.Properties[leafKey]
+	if !exists {
+		leaf = &Schema{Placeholder: true, HelmPath: strings.TrimPrefix(node.HelmPath+"."+leafKey, ".")}
+		node.Properties[leafKey] = leaf
+	}
+
+	if ref.description != "" && leaf.Description == "" {
+		leaf.Description = ref.description
+	}
+	if ref.required {
+		addRequired(node, leafKey)
+	}
+}
+
+// ensureObjectProperty returns node.Properties[key], creating it as an
+// empty object schema if it isn't already present.
+func ensureObjectProperty(node *Schema, key string) *Schema {
+	if node.Properties == nil {
+		node.Properties = make(map[string]*Schema)
+	}
+	child, ok := node.Properties[key]
+	if !ok {
+		child = &Schema{
+			Type:       TypeObject,
+			Properties: make(map[string]*Schema),
+			HelmPath:   strings.TrimPrefix(node.HelmPath+"."+key, "."),
+		}
+		node.Properties[key] = child
+	}
+	return child
+}
+
+// addRequired appends key to node.Required if it isn't already present.
+func addRequired(node *Schema, key string) {
+	for _, existing := range node.Required {
+		if existing == key {
+			return
+		}
+	}
+	node.Required = append(node.Required, key)
+}
@@ -0,0 +1,136 @@
+package jsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeChartFile writes contents to chartDir/relPath, creating parent
+// directories as needed.
+func writeChartFile(t *testing.T, chartDir, relPath, contents string) {
+	t.Helper()
+	fullPath := filepath.Join(chartDir, relPath)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0750); err != nil {
+		t.Fatalf("failed to create directory for %s: %v", relPath, err)
+	}
+	if err := os.WriteFile(fullPath, []byte(contents), 0600); err != nil {
+		t.Fatalf("failed to write %s: %v", relPath, err)
+	}
+}
+
+func TestGenerateFromChart_RequiredFromTemplate(t *testing.T) {
+	ctx := context.Background()
+	chartDir := t.TempDir()
+
+	writeChartFile(t, chartDir, "values.yaml", "image:\n  repository: nginx\n")
+	writeChartFile(t, chartDir, "templates/deployment.yaml", `
+image: {{ required "image.tag is required" .Values.image.tag }}
+`)
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+	schema, err := generator.GenerateFromChart(ctx, chartDir)
+	if err != nil {
+		t.Fatalf("GenerateFromChart failed: %v", err)
+	}
+
+	image := schema.Properties["image"]
+	if image == nil {
+		t.Fatalf("expected an image property")
+	}
+
+	requiredSet := map[string]bool{}
+	for _, r := range image.Required {
+		requiredSet[r] = true
+	}
+	if !requiredSet["tag"] {
+		t.Errorf("expected image.tag to be required, got required=%v", image.Required)
+	}
+
+	if _, ok := image.Properties["tag"]; !ok {
+		t.Errorf("expected image.tag to exist as a placeholder property")
+	}
+}
+
+func TestGenerateFromChart_PlaceholderForMissingPath(t *testing.T) {
+	ctx := context.Background()
+	chartDir := t.TempDir()
+
+	writeChartFile(t, chartDir, "values.yaml", "replicaCount: 1\n")
+	writeChartFile(t, chartDir, "templates/service.yaml", `
+port: {{ .Values.service.port }}
+`)
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+	schema, err := generator.GenerateFromChart(ctx, chartDir)
+	if err != nil {
+		t.Fatalf("GenerateFromChart failed: %v", err)
+	}
+
+	service := schema.Properties["service"]
+	if service == nil {
+		t.Fatalf("expected a service property to be synthesized")
+	}
+	port := service.Properties["port"]
+	if port == nil {
+		t.Fatalf("expected service.port to be synthesized")
+	}
+	if !port.Placeholder {
+		t.Errorf("expected service.port to be marked as a placeholder")
+	}
+
+	data, err := port.MarshalJSON()
+	if err != nil {
+		t.Fatalf("failed to marshal placeholder schema: %v", err)
+	}
+	var rendered map[string]any
+	if err := json.Unmarshal(data, &rendered); err != nil {
+		t.Fatalf("failed to unmarshal rendered schema: %v", err)
+	}
+	if _, ok := rendered["default"]; !ok {
+		t.Errorf("expected rendered placeholder to include an explicit default, got %v", rendered)
+	}
+}
+
+func TestGenerateFromChart_CommentBecomesDescription(t *testing.T) {
+	ctx := context.Background()
+	chartDir := t.TempDir()
+
+	writeChartFile(t, chartDir, "values.yaml", "replicaCount: 1\n")
+	writeChartFile(t, chartDir, "templates/deployment.yaml", `
+{{- /* Number of pod replicas to run */ -}}
+replicas: {{ .Values.replicaCount }}
+`)
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07, ExtractDescriptions: false})
+	schema, err := generator.GenerateFromChart(ctx, chartDir)
+	if err != nil {
+		t.Fatalf("GenerateFromChart failed: %v", err)
+	}
+
+	replicaCount := schema.Properties["replicaCount"]
+	if replicaCount == nil {
+		t.Fatalf("expected replicaCount property")
+	}
+	if replicaCount.Description != "Number of pod replicas to run" {
+		t.Errorf("expected description from template comment, got %q", replicaCount.Description)
+	}
+}
+
+func TestGenerateFromChart_NoTemplatesDirectory(t *testing.T) {
+	ctx := context.Background()
+	chartDir := t.TempDir()
+
+	writeChartFile(t, chartDir, "values.yaml", "replicaCount: 1\n")
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+	schema, err := generator.GenerateFromChart(ctx, chartDir)
+	if err != nil {
+		t.Fatalf("GenerateFromChart failed: %v", err)
+	}
+	if schema.Properties["replicaCount"] == nil {
+		t.Errorf("expected values.yaml fields to still be inferred with no templates directory")
+	}
+}
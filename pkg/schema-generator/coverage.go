@@ -0,0 +1,326 @@
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// PathCoverage records how a single schema path fared against the values
+// documents passed to ComputeCoverage.
+type PathCoverage struct {
+	// Path is the dotted Helm path of the schema property (e.g.
+	// "resources.limits.cpu"), with "[]" appended for array item schemas.
+	Path string `json:"path"`
+	// Required is whether the owning object schema lists this property in
+	// its "required" array.
+	Required bool `json:"required"`
+	// HitCount is the number of documents passed to ComputeCoverage that set
+	// this path at least once.
+	HitCount int `json:"hitCount"`
+}
+
+// CoverageReport is the result of ComputeCoverage: every schema path found,
+// whether it was actually set by the provided values documents, which
+// required paths were never set by any of them, and which paths those
+// documents used that the schema doesn't define.
+type CoverageReport struct {
+	// DocumentCount is the number of values documents ComputeCoverage was
+	// given.
+	DocumentCount int `json:"documentCount"`
+	// Paths covers every property in schema, in sorted order.
+	Paths []PathCoverage `json:"paths"`
+	// UncoveredRequired lists required paths with a zero HitCount across all
+	// documents - values.yaml files that never actually supply a field the
+	// schema insists on.
+	UncoveredRequired []string `json:"uncoveredRequired"`
+	// UnknownPaths lists paths present in at least one document that have
+	// no corresponding node anywhere in schema - values added without a
+	// matching schema update.
+	UnknownPaths []string `json:"unknownPaths"`
+}
+
+// Issues renders report as ValidationIssues so it can be displayed with
+// FormatValidationIssues alongside schema-quality and values-conformance
+// issues. A schema path that's never exercised by any document is Info; an
+// uncovered required field or an unknown document path is a Warning, since
+// both usually indicate the schema and the real values.yaml files have
+// drifted apart.
+func (report *CoverageReport) Issues() []ValidationIssue {
+	issues := make([]ValidationIssue, 0, len(report.Paths)+len(report.UncoveredRequired)+len(report.UnknownPaths))
+
+	for _, pc := range report.Paths {
+		if pc.HitCount == 0 && !pc.Required {
+			issues = append(issues, ValidationIssue{
+				Path:    pc.Path,
+				Message: "schema property is never set by any provided values file",
+				Level:   Info,
+			})
+		}
+	}
+
+	for _, path := range report.UncoveredRequired {
+		issues = append(issues, ValidationIssue{
+			Path:    path,
+			Message: "required field is never set by any provided values file",
+			Level:   Warning,
+		})
+	}
+
+	for _, path := range report.UnknownPaths {
+		issues = append(issues, ValidationIssue{
+			Path:    path,
+			Message: "values path has no corresponding schema property",
+			Level:   Warning,
+		})
+	}
+
+	return issues
+}
+
+// ComputeCoverage reports which paths in schema are actually exercised by
+// docs - typically real values.yaml files (production, staging, examples)
+// parsed into map[string]any - which required paths none of them ever set,
+// and which paths in docs have no corresponding schema node at all.
+func ComputeCoverage(schema *Schema, docs []map[string]any) *CoverageReport {
+	defs := schemaDefs(schema)
+
+	coverage := make(map[string]*PathCoverage)
+	requiredSeen := make(map[string]bool)
+	collectCoveragePaths(schema, "", defs, coverage, requiredSeen, map[string]bool{})
+
+	for _, doc := range docs {
+		seen := make(map[string]bool)
+		recordSeenPaths(doc, "", seen)
+		for path := range seen {
+			if pc, ok := coverage[path]; ok {
+				pc.HitCount++
+			}
+		}
+	}
+
+	unknown := make(map[string]bool)
+	for _, doc := range docs {
+		collectUnknownPaths(doc, "", nil, schema, defs, unknown)
+	}
+
+	report := &CoverageReport{DocumentCount: len(docs)}
+
+	paths := make([]string, 0, len(coverage))
+	for path := range coverage {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	report.Paths = make([]PathCoverage, 0, len(paths))
+	for _, path := range paths {
+		report.Paths = append(report.Paths, *coverage[path])
+	}
+
+	for path := range requiredSeen {
+		if coverage[path].HitCount == 0 {
+			report.UncoveredRequired = append(report.UncoveredRequired, path)
+		}
+	}
+	sort.Strings(report.UncoveredRequired)
+
+	unknownPaths := make([]string, 0, len(unknown))
+	for path := range unknown {
+		unknownPaths = append(unknownPaths, path)
+	}
+	sort.Strings(unknownPaths)
+	report.UnknownPaths = unknownPaths
+
+	return report
+}
+
+// LoadValuesDocument parses a values.yaml document into the
+// map[string]any shape ComputeCoverage (and most of this package's other
+// YAML-consuming APIs) expects.
+func LoadValuesDocument(data []byte) (map[string]any, error) {
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal values: %w", err)
+	}
+
+	converted, err := convertYAMLToStringMap(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert values: %w", err)
+	}
+
+	doc, ok := converted.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("root YAML value must be a map, got %T", converted)
+	}
+
+	return doc, nil
+}
+
+// schemaDefs merges schema's $defs and definitions buckets into a single
+// lookup keyed by def name, for resolving $ref during coverage traversal.
+func schemaDefs(schema *Schema) map[string]*Schema {
+	defs := make(map[string]*Schema, len(schema.Defs)+len(schema.Definitions))
+	for name, def := range schema.Defs {
+		defs[name] = def
+	}
+	for name, def := range schema.Definitions {
+		defs[name] = def
+	}
+	return defs
+}
+
+// defNameFromRef extracts the def name from a "#/$defs/Name" or
+// "#/definitions/Name" JSON Pointer.
+func defNameFromRef(ref string) string {
+	for i := len(ref) - 1; i >= 0; i-- {
+		if ref[i] == '/' {
+			return ref[i+1:]
+		}
+	}
+	return ref
+}
+
+// derefSchema resolves schema's $ref (if any) against defs, returning
+// schema unchanged if it isn't a reference.
+func derefSchema(schema *Schema, defs map[string]*Schema) *Schema {
+	if schema == nil || schema.Ref == "" {
+		return schema
+	}
+	return defs[defNameFromRef(schema.Ref)]
+}
+
+// collectCoveragePaths walks schema (dereferencing $ref via defs), recording
+// every property path it finds in coverage and every required path in
+// requiredSeen. visitedRefs guards against infinite recursion through a
+// self-referential $ref chain without preventing the same def from being
+// expanded again from a sibling branch.
+func collectCoveragePaths(schema *Schema, prefix string, defs map[string]*Schema, coverage map[string]*PathCoverage, requiredSeen map[string]bool, visitedRefs map[string]bool) {
+	if schema != nil && schema.Ref != "" {
+		name := defNameFromRef(schema.Ref)
+		if visitedRefs[name] {
+			return
+		}
+		visitedRefs = cloneVisitedRefs(visitedRefs)
+		visitedRefs[name] = true
+	}
+	schema = derefSchema(schema, defs)
+	if schema == nil {
+		return
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	for name, prop := range schema.Properties {
+		childPath := name
+		if prefix != "" {
+			childPath = prefix + "." + name
+		}
+
+		if _, exists := coverage[childPath]; !exists {
+			coverage[childPath] = &PathCoverage{Path: childPath}
+		}
+		if required[name] {
+			coverage[childPath].Required = true
+			requiredSeen[childPath] = true
+		}
+
+		collectCoveragePaths(prop, childPath, defs, coverage, requiredSeen, visitedRefs)
+	}
+
+	if schema.Items != nil {
+		collectCoveragePaths(schema.Items, prefix+"[]", defs, coverage, requiredSeen, visitedRefs)
+	}
+}
+
+// cloneVisitedRefs copies visited so a sibling branch's recursion doesn't
+// see refs expanded along a different branch as already visited.
+func cloneVisitedRefs(visited map[string]bool) map[string]bool {
+	clone := make(map[string]bool, len(visited)+1)
+	for name := range visited {
+		clone[name] = true
+	}
+	return clone
+}
+
+// recordSeenPaths walks a parsed values document, marking every path it
+// sets in seen so ComputeCoverage can count, per document, which schema
+// paths it exercised.
+func recordSeenPaths(value any, prefix string, seen map[string]bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, val := range v {
+			childPath := key
+			if prefix != "" {
+				childPath = prefix + "." + key
+			}
+			seen[childPath] = true
+			recordSeenPaths(val, childPath, seen)
+		}
+	case []any:
+		itemPath := prefix + "[]"
+		for _, item := range v {
+			seen[itemPath] = true
+			recordSeenPaths(item, itemPath, seen)
+		}
+	}
+}
+
+// collectUnknownPaths walks a parsed values document, resolving each path it
+// sets against schema (dereferencing $ref via defs) and recording any path
+// with no corresponding schema node in unknown. It doesn't recurse past an
+// already-unknown path, since the schema has nothing to say about its
+// descendants either.
+func collectUnknownPaths(value any, prefix string, segments []string, schema *Schema, defs map[string]*Schema, unknown map[string]bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, val := range v {
+			childPath := key
+			if prefix != "" {
+				childPath = prefix + "." + key
+			}
+			childSegments := append(append([]string{}, segments...), key)
+
+			if resolveSchemaPath(schema, defs, childSegments) == nil {
+				unknown[childPath] = true
+				continue
+			}
+			collectUnknownPaths(val, childPath, childSegments, schema, defs, unknown)
+		}
+	case []any:
+		itemPath := prefix + "[]"
+		itemSegments := append(append([]string{}, segments...), "[]")
+
+		if resolveSchemaPath(schema, defs, itemSegments) == nil {
+			unknown[itemPath] = true
+			return
+		}
+		for _, item := range v {
+			collectUnknownPaths(item, itemPath, itemSegments, schema, defs, unknown)
+		}
+	}
+}
+
+// resolveSchemaPath walks schema from the root along segments (each a
+// property name, or "[]" for an array's item schema), dereferencing $ref via
+// defs at each step, and returns the node at that path or nil if segments
+// takes a path the schema doesn't define.
+func resolveSchemaPath(schema *Schema, defs map[string]*Schema, segments []string) *Schema {
+	node := derefSchema(schema, defs)
+	for _, seg := range segments {
+		if node == nil {
+			return nil
+		}
+		if seg == "[]" {
+			node = derefSchema(node.Items, defs)
+			continue
+		}
+		if node.Properties == nil {
+			return nil
+		}
+		node = derefSchema(node.Properties[seg], defs)
+	}
+	return node
+}
@@ -0,0 +1,198 @@
+package jsonschema
+
+import "testing"
+
+func TestComputeCoverage_HitCountsAndUncoveredRequired(t *testing.T) {
+	schema := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"image": {
+				Type:     TypeObject,
+				Required: []string{"repository"},
+				Properties: map[string]*Schema{
+					"repository": {Type: TypeString},
+					"tag":        {Type: TypeString},
+				},
+			},
+			"replicaCount": {Type: TypeInteger},
+		},
+	}
+
+	docs := []map[string]any{
+		{"image": map[string]any{"repository": "nginx"}},
+		{"image": map[string]any{"repository": "nginx", "tag": "1.25"}},
+	}
+
+	report := ComputeCoverage(schema, docs)
+
+	if report.DocumentCount != 2 {
+		t.Fatalf("expected DocumentCount 2, got %d", report.DocumentCount)
+	}
+
+	hits := make(map[string]PathCoverage)
+	for _, pc := range report.Paths {
+		hits[pc.Path] = pc
+	}
+
+	if hits["image.repository"].HitCount != 2 {
+		t.Errorf("expected image.repository hit count 2, got %d", hits["image.repository"].HitCount)
+	}
+	if !hits["image.repository"].Required {
+		t.Error("expected image.repository to be marked required")
+	}
+	if hits["image.tag"].HitCount != 1 {
+		t.Errorf("expected image.tag hit count 1, got %d", hits["image.tag"].HitCount)
+	}
+	if hits["replicaCount"].HitCount != 0 {
+		t.Errorf("expected replicaCount hit count 0, got %d", hits["replicaCount"].HitCount)
+	}
+
+	if len(report.UncoveredRequired) != 0 {
+		t.Errorf("expected no uncovered required paths, got %v", report.UncoveredRequired)
+	}
+}
+
+func TestComputeCoverage_UncoveredRequiredField(t *testing.T) {
+	schema := &Schema{
+		Type:     TypeObject,
+		Required: []string{"replicaCount"},
+		Properties: map[string]*Schema{
+			"replicaCount": {Type: TypeInteger},
+		},
+	}
+
+	report := ComputeCoverage(schema, []map[string]any{{"unrelated": true}})
+
+	if len(report.UncoveredRequired) != 1 || report.UncoveredRequired[0] != "replicaCount" {
+		t.Errorf("expected UncoveredRequired [replicaCount], got %v", report.UncoveredRequired)
+	}
+}
+
+func TestComputeCoverage_UnknownPaths(t *testing.T) {
+	schema := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"image": {
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"repository": {Type: TypeString},
+				},
+			},
+		},
+	}
+
+	docs := []map[string]any{
+		{"image": map[string]any{"repository": "nginx", "pullPolicy": "Always"}},
+		{"extraTopLevel": "value"},
+	}
+
+	report := ComputeCoverage(schema, docs)
+
+	expected := map[string]bool{"image.pullPolicy": true, "extraTopLevel": true}
+	if len(report.UnknownPaths) != len(expected) {
+		t.Fatalf("expected %d unknown paths, got %v", len(expected), report.UnknownPaths)
+	}
+	for _, path := range report.UnknownPaths {
+		if !expected[path] {
+			t.Errorf("unexpected unknown path %q", path)
+		}
+	}
+}
+
+func TestComputeCoverage_RefAndArrayAwarePaths(t *testing.T) {
+	schema := &Schema{
+		Type: TypeObject,
+		Defs: map[string]*Schema{
+			"port": {
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"name":   {Type: TypeString},
+					"number": {Type: TypeInteger},
+				},
+			},
+		},
+		Properties: map[string]*Schema{
+			"ports": {
+				Type:  TypeArray,
+				Items: &Schema{Ref: "#/$defs/port"},
+			},
+		},
+	}
+
+	docs := []map[string]any{
+		{"ports": []any{map[string]any{"name": "http", "number": 8080}}},
+	}
+
+	report := ComputeCoverage(schema, docs)
+
+	hits := make(map[string]PathCoverage)
+	for _, pc := range report.Paths {
+		hits[pc.Path] = pc
+	}
+
+	if _, ok := hits["ports[].name"]; !ok {
+		t.Fatalf("expected ports[].name in coverage paths, got %v", report.Paths)
+	}
+	if hits["ports[].name"].HitCount != 1 {
+		t.Errorf("expected ports[].name hit count 1, got %d", hits["ports[].name"].HitCount)
+	}
+	if hits["ports[].number"].HitCount != 1 {
+		t.Errorf("expected ports[].number hit count 1, got %d", hits["ports[].number"].HitCount)
+	}
+	if len(report.UnknownPaths) != 0 {
+		t.Errorf("expected no unknown paths, got %v", report.UnknownPaths)
+	}
+}
+
+func TestCoverageReport_Issues(t *testing.T) {
+	report := &CoverageReport{
+		DocumentCount: 1,
+		Paths: []PathCoverage{
+			{Path: "optionalUnused", Required: false, HitCount: 0},
+			{Path: "used", Required: false, HitCount: 1},
+		},
+		UncoveredRequired: []string{"requiredUnused"},
+		UnknownPaths:      []string{"strayField"},
+	}
+
+	issues := report.Issues()
+
+	levels := make(map[string]ValidationLevel)
+	for _, issue := range issues {
+		levels[issue.Path] = issue.Level
+	}
+
+	if levels["optionalUnused"] != Info {
+		t.Errorf("expected optionalUnused issue at Info level, got %v", levels["optionalUnused"])
+	}
+	if _, ok := levels["used"]; ok {
+		t.Error("did not expect an issue for a covered path")
+	}
+	if levels["requiredUnused"] != Warning {
+		t.Errorf("expected requiredUnused issue at Warning level, got %v", levels["requiredUnused"])
+	}
+	if levels["strayField"] != Warning {
+		t.Errorf("expected strayField issue at Warning level, got %v", levels["strayField"])
+	}
+}
+
+func TestLoadValuesDocument(t *testing.T) {
+	doc, err := LoadValuesDocument([]byte("replicaCount: 2\nimage:\n  repository: nginx\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	image, ok := doc["image"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected image to be a map[string]any, got %T", doc["image"])
+	}
+	if image["repository"] != "nginx" {
+		t.Errorf("expected image.repository nginx, got %v", image["repository"])
+	}
+}
+
+func TestLoadValuesDocument_RejectsNonMapRoot(t *testing.T) {
+	if _, err := LoadValuesDocument([]byte("- one\n- two\n")); err == nil {
+		t.Error("expected an error for a non-map root document")
+	}
+}
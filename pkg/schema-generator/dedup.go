@@ -0,0 +1,303 @@
+package jsonschema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// canonicalSchema is a stripped-down, structure-only view of a Schema used to
+// decide whether two subschemas have the same shape. Descriptions, examples,
+// and defaults are deliberately excluded - only the parts that affect
+// validation are compared.
+type canonicalSchema struct {
+	Type       any                         `json:"type,omitempty"`
+	Properties map[string]*canonicalSchema `json:"properties,omitempty"`
+	Required   []string                    `json:"required,omitempty"`
+	Items      *canonicalSchema            `json:"items,omitempty"`
+	TupleItems []*canonicalSchema          `json:"tupleItems,omitempty"`
+}
+
+// dedupOccurrence tracks one place in the schema tree where a candidate
+// subschema was found, along with a setter to replace it with a $ref once a
+// hoisting decision has been made.
+type dedupOccurrence struct {
+	schema *Schema
+	path   string
+	setRef func(*Schema)
+}
+
+// deduplicateSchemas walks schema, hoists object subschemas that appear at
+// least Options.DedupThreshold times in structurally identical form into a
+// shared $defs (Draft 2019-09/2020-12) or definitions (Draft-07 and earlier)
+// bucket, and replaces each occurrence with a $ref to it.
+func (g *Generator) deduplicateSchemas(schema *Schema) {
+	threshold := g.Options.DedupThreshold
+	if threshold <= 0 {
+		threshold = 2
+	}
+	minProperties := g.Options.DedupMinProperties
+	if minProperties <= 0 {
+		minProperties = 1
+	}
+
+	groups := make(map[string][]dedupOccurrence)
+	collectDedupCandidates(schema, nil, minProperties, groups)
+
+	hashes := make([]string, 0, len(groups))
+	for hash := range groups {
+		hashes = append(hashes, hash)
+	}
+	// Process shallower (outer) shapes before deeper (inner) ones, so that
+	// once an outer shape like "resources" is hoisted, its still-identical
+	// inner shapes like "limits"/"requests" aren't redundantly hoisted again
+	// from inside the single shared definition.
+	sort.Slice(hashes, func(i, j int) bool {
+		di, dj := occurrenceDepth(groups[hashes[i]]), occurrenceDepth(groups[hashes[j]])
+		if di != dj {
+			return di < dj
+		}
+		return hashes[i] < hashes[j]
+	})
+
+	usedNames := make(map[string]bool)
+	var defs map[string]*Schema
+	var acceptedPaths []string
+
+	for _, hash := range hashes {
+		occurrences := groups[hash]
+
+		remaining := occurrences[:0:0]
+		for _, occ := range occurrences {
+			if !nestedUnderAny(occ.path, acceptedPaths) {
+				remaining = append(remaining, occ)
+			}
+		}
+		if len(remaining) < threshold {
+			continue
+		}
+
+		name := uniqueDefName(deriveDefName(remaining), usedNames)
+		usedNames[name] = true
+
+		if defs == nil {
+			defs = make(map[string]*Schema)
+		}
+		defs[name] = remaining[0].schema
+
+		ref := defRef(g.Options.SchemaVersion, name)
+		for _, occ := range remaining {
+			occ.setRef(&Schema{Ref: ref})
+			acceptedPaths = append(acceptedPaths, occ.path)
+		}
+	}
+
+	if usesDollarDefs(g.Options.SchemaVersion) {
+		schema.Defs = defs
+	} else {
+		schema.Definitions = defs
+	}
+}
+
+// collectDedupCandidates records every object subschema below (and
+// excluding) the root, grouped by canonical shape. setRef is nil for the
+// root itself, since the root schema can never be replaced with a $ref.
+func collectDedupCandidates(s *Schema, setRef func(*Schema), minProperties int, groups map[string][]dedupOccurrence) {
+	if s == nil {
+		return
+	}
+
+	for key, child := range s.Properties {
+		key := key
+		parent := s
+		collectDedupCandidates(child, func(n *Schema) { parent.Properties[key] = n }, minProperties, groups)
+	}
+
+	if s.Items != nil {
+		parent := s
+		collectDedupCandidates(s.Items, func(n *Schema) { parent.Items = n }, minProperties, groups)
+	}
+
+	for i, item := range s.TuplePrefixItems {
+		i := i
+		collectDedupCandidates(item, func(n *Schema) { s.TuplePrefixItems[i] = n }, minProperties, groups)
+	}
+	for i, item := range s.TupleLegacyItems {
+		i := i
+		collectDedupCandidates(item, func(n *Schema) { s.TupleLegacyItems[i] = n }, minProperties, groups)
+	}
+
+	if setRef == nil {
+		return
+	}
+
+	// A schema is a hoisting candidate if it describes an object shape with
+	// enough properties, regardless of whether pattern rules additionally
+	// widened its Type to a union like [string, object].
+	if len(s.Properties) < minProperties {
+		return
+	}
+
+	hash := canonicalHash(s)
+	groups[hash] = append(groups[hash], dedupOccurrence{schema: s, path: s.HelmPath, setRef: setRef})
+}
+
+// occurrenceDepth returns the shallowest Helm path depth among a group's
+// occurrences, used to hoist outer shapes before the inner shapes they
+// contain.
+func occurrenceDepth(occurrences []dedupOccurrence) int {
+	depth := -1
+	for _, occ := range occurrences {
+		d := strings.Count(occ.path, ".")
+		if depth == -1 || d < depth {
+			depth = d
+		}
+	}
+	return depth
+}
+
+// nestedUnderAny reports whether path is strictly nested under any of the
+// given ancestor paths.
+func nestedUnderAny(path string, ancestors []string) bool {
+	for _, ancestor := range ancestors {
+		if strings.HasPrefix(path, ancestor+".") || strings.HasPrefix(path, ancestor+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalize strips a Schema down to the parts that determine its shape.
+func canonicalize(s *Schema) *canonicalSchema {
+	if s == nil {
+		return nil
+	}
+
+	c := &canonicalSchema{Type: normalizeSchemaType(s.Type)}
+
+	if len(s.Properties) > 0 {
+		c.Properties = make(map[string]*canonicalSchema, len(s.Properties))
+		for key, prop := range s.Properties {
+			c.Properties[key] = canonicalize(prop)
+		}
+	}
+
+	if len(s.Required) > 0 {
+		required := append([]string{}, s.Required...)
+		sort.Strings(required)
+		c.Required = required
+	}
+
+	if s.Items != nil {
+		c.Items = canonicalize(s.Items)
+	}
+
+	tupleItems := s.TuplePrefixItems
+	if len(tupleItems) == 0 {
+		tupleItems = s.TupleLegacyItems
+	}
+	for _, item := range tupleItems {
+		c.TupleItems = append(c.TupleItems, canonicalize(item))
+	}
+
+	return c
+}
+
+// normalizeSchemaType sorts multi-type arrays so equivalent type sets compare
+// equal regardless of the order they were discovered in.
+func normalizeSchemaType(t any) any {
+	types, ok := t.([]SchemaType)
+	if !ok {
+		return t
+	}
+	sorted := append([]SchemaType{}, types...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// canonicalHash returns a stable hash of a schema's shape, suitable for
+// grouping structurally identical subschemas.
+func canonicalHash(s *Schema) string {
+	// encoding/json marshals map keys in sorted order, so this is stable
+	// regardless of Go's randomized map iteration order.
+	data, err := json.Marshal(canonicalize(s))
+	if err != nil {
+		// Shapes are plain data; Marshal cannot fail here in practice.
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// deriveDefName picks a stable name for a hoisted definition from the most
+// common terminal path segment among its occurrences (e.g. "resources" for
+// paths like "podA.resources" and "podB.resources").
+func deriveDefName(occurrences []dedupOccurrence) string {
+	counts := make(map[string]int)
+	for _, occ := range occurrences {
+		counts[lastPathSegment(occ.path)]++
+	}
+
+	candidates := make([]string, 0, len(counts))
+	for name := range counts {
+		candidates = append(candidates, name)
+	}
+	sort.Strings(candidates)
+
+	best := "definition"
+	bestCount := 0
+	for _, name := range candidates {
+		if name != "" && counts[name] > bestCount {
+			best = name
+			bestCount = counts[name]
+		}
+	}
+
+	return best
+}
+
+// lastPathSegment extracts the final dot-notation segment of a Helm value
+// path, stripping any array-index suffix.
+func lastPathSegment(path string) string {
+	if strings.HasSuffix(path, "]") {
+		if idx := strings.LastIndex(path, "["); idx >= 0 {
+			path = path[:idx]
+		}
+	}
+	if idx := strings.LastIndex(path, "."); idx >= 0 {
+		return path[idx+1:]
+	}
+	return path
+}
+
+// uniqueDefName appends a numeric suffix to base until it no longer collides
+// with a name already used in this schema's $defs/definitions bucket.
+func uniqueDefName(base string, used map[string]bool) string {
+	if !used[base] {
+		return base
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s%d", base, i)
+		if !used[candidate] {
+			return candidate
+		}
+	}
+}
+
+// defRef builds the $ref string pointing at a hoisted definition, using
+// $defs for Draft 2019-09/2020-12 and definitions otherwise.
+func defRef(version SchemaVersion, name string) string {
+	if usesDollarDefs(version) {
+		return "#/$defs/" + name
+	}
+	return "#/definitions/" + name
+}
+
+// usesDollarDefs reports whether a schema version uses $defs rather than
+// the legacy definitions keyword.
+func usesDollarDefs(version SchemaVersion) bool {
+	return version == Draft2019 || version == Draft2020
+}
@@ -0,0 +1,197 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDeduplicateSchemas_RepeatedObjectSubschemas(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion: Draft07,
+	})
+
+	yamlData := []byte(`
+podA:
+  podALabel: a
+  resources:
+    limits:
+      cpu: "500m"
+      memory: "256Mi"
+    requests:
+      cpu: "250m"
+      memory: "128Mi"
+podB:
+  podBLabel: b
+  resources:
+    limits:
+      cpu: "500m"
+      memory: "256Mi"
+    requests:
+      cpu: "250m"
+      memory: "128Mi"
+podC:
+  podCLabel: c
+  resources:
+    limits:
+      cpu: "500m"
+      memory: "256Mi"
+    requests:
+      cpu: "250m"
+      memory: "128Mi"
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	if len(schema.Definitions) != 1 {
+		t.Fatalf("expected exactly one hoisted definition, got %d: %v", len(schema.Definitions), schema.Definitions)
+	}
+
+	var defName string
+	for name := range schema.Definitions {
+		defName = name
+	}
+
+	wantRef := "#/definitions/" + defName
+	for _, pod := range []string{"podA", "podB", "podC"} {
+		resources := schema.Properties[pod].Properties["resources"]
+		if resources.Ref != wantRef {
+			t.Errorf("%s.resources: expected $ref %q, got %q", pod, wantRef, resources.Ref)
+		}
+	}
+}
+
+func TestDeduplicateSchemas_BelowThresholdIsNotHoisted(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion: Draft07,
+	})
+
+	yamlData := []byte(`
+podA:
+  resources:
+    cpu: "500m"
+podB:
+  other: true
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	if len(schema.Definitions) != 0 {
+		t.Errorf("expected no hoisted definitions for a single occurrence, got %d", len(schema.Definitions))
+	}
+}
+
+func TestDeduplicateSchemas_RespectsMinProperties(t *testing.T) {
+	ctx := context.Background()
+
+	options := DefaultOptions()
+	options.DedupMinProperties = 3
+
+	generator := NewGenerator(options)
+
+	yamlData := []byte(`
+podA:
+  toggle:
+    enabled: true
+podB:
+  toggle:
+    enabled: true
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	if len(schema.Definitions) != 0 {
+		t.Errorf("expected single-property objects to be skipped with DedupMinProperties=3, got %d definitions", len(schema.Definitions))
+	}
+}
+
+func TestDeduplicateSchemas_UsesDollarDefsForNewerDrafts(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion: Draft2020,
+	})
+
+	yamlData := []byte(`
+podA:
+  podALabel: a
+  resources:
+    cpu: "500m"
+    memory: "1Gi"
+podB:
+  podBLabel: b
+  resources:
+    cpu: "500m"
+    memory: "1Gi"
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	if len(schema.Defs) != 1 {
+		t.Fatalf("expected one entry under $defs for Draft2020, got %d", len(schema.Defs))
+	}
+	if len(schema.Definitions) != 0 {
+		t.Errorf("expected no legacy definitions bucket for Draft2020, got %d", len(schema.Definitions))
+	}
+}
+
+func TestDeduplicateSchemas_NameCollisionGetsSuffix(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion: Draft07,
+	})
+
+	yamlData := []byte(`
+serviceA:
+  serviceALabel: a
+  config:
+    level: "info"
+    format: "json"
+serviceB:
+  serviceBLabel: b
+  config:
+    level: "info"
+    format: "json"
+serviceC:
+  serviceCLabel: c
+  config:
+    timeout: 30
+    retries: 3
+serviceD:
+  serviceDLabel: d
+  config:
+    timeout: 30
+    retries: 3
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	if len(schema.Definitions) != 2 {
+		t.Fatalf("expected two distinct hoisted shapes named after 'config', got %d: %v", len(schema.Definitions), schema.Definitions)
+	}
+	if _, ok := schema.Definitions["config"]; !ok {
+		t.Errorf("expected a definition named 'config', got %v", schema.Definitions)
+	}
+	if _, ok := schema.Definitions["config2"]; !ok {
+		t.Errorf("expected the colliding shape to be named 'config2', got %v", schema.Definitions)
+	}
+}
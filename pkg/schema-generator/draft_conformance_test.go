@@ -0,0 +1,63 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+// TestDraftConformanceMatrix round-trips a generated schema through the real
+// JSON Schema validator (see compileAndValidate) for every supported draft,
+// confirming the schema compiles under that draft and validates both a
+// passing and a failing values document as expected.
+func TestDraftConformanceMatrix(t *testing.T) {
+	versions := []SchemaVersion{Draft4, Draft6, Draft07, Draft2019, Draft2020}
+
+	yamlData := []byte(`
+replicaCount: 1
+image:
+  repository: nginx
+  tag: "1.25"
+`)
+
+	passingValues := []byte(`
+replicaCount: 3
+image:
+  repository: myapp
+  tag: "2.0"
+`)
+
+	failingValues := []byte(`
+replicaCount: "not-a-number"
+image:
+  repository: myapp
+  tag: "2.0"
+`)
+
+	for _, version := range versions {
+		t.Run(string(version), func(t *testing.T) {
+			ctx := context.Background()
+			generator := NewGenerator(GeneratorOptions{SchemaVersion: version})
+
+			schema, err := generator.GenerateFromYAML(ctx, yamlData)
+			if err != nil {
+				t.Fatalf("GenerateFromYAML failed: %v", err)
+			}
+
+			errs, err := generator.Validate(ctx, schema, passingValues)
+			if err != nil {
+				t.Fatalf("Validate failed to compile/validate passing values: %v", err)
+			}
+			if len(errs) != 0 {
+				t.Errorf("expected no validation errors for passing values, got %+v", errs)
+			}
+
+			errs, err = generator.Validate(ctx, schema, failingValues)
+			if err != nil {
+				t.Fatalf("Validate failed to compile/validate failing values: %v", err)
+			}
+			if len(errs) != 1 {
+				t.Errorf("expected 1 validation error for failing values, got %d: %+v", len(errs), errs)
+			}
+		})
+	}
+}
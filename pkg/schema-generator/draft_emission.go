@@ -0,0 +1,47 @@
+package jsonschema
+
+// applyUnevaluatedProperties walks schema and every subschema reachable from
+// it, setting UnevaluatedProperties to false on each object-typed node, so
+// the generated document is fully closed to unexpected keys. It's a no-op
+// for drafts that don't support the keyword (see
+// GeneratorOptions.DisallowUnevaluatedProperties).
+func applyUnevaluatedProperties(schema *Schema, version SchemaVersion) {
+	if schema == nil || !usesDollarDefs(version) {
+		return
+	}
+
+	disallowed := false
+	walkSchemas(schema, func(s *Schema) {
+		if s.Properties != nil {
+			s.UnevaluatedProperties = &disallowed
+		}
+	})
+}
+
+// walkSchemas calls visit on schema and every subschema reachable through
+// Properties, Items, TuplePrefixItems, TupleLegacyItems, Defs, and
+// Definitions.
+func walkSchemas(schema *Schema, visit func(*Schema)) {
+	if schema == nil {
+		return
+	}
+
+	visit(schema)
+
+	for _, prop := range schema.Properties {
+		walkSchemas(prop, visit)
+	}
+	walkSchemas(schema.Items, visit)
+	for _, item := range schema.TuplePrefixItems {
+		walkSchemas(item, visit)
+	}
+	for _, item := range schema.TupleLegacyItems {
+		walkSchemas(item, visit)
+	}
+	for _, def := range schema.Defs {
+		walkSchemas(def, visit)
+	}
+	for _, def := range schema.Definitions {
+		walkSchemas(def, visit)
+	}
+}
@@ -0,0 +1,86 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseDraftShorthand(t *testing.T) {
+	tests := []struct {
+		shorthand string
+		want      SchemaVersion
+		wantErr   bool
+	}{
+		{"4", Draft4, false},
+		{"6", Draft6, false},
+		{"7", Draft07, false},
+		{"2019", Draft2019, false},
+		{"2020", Draft2020, false},
+		{"99", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.shorthand, func(t *testing.T) {
+			got, err := ParseDraftShorthand(test.shorthand)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for shorthand %q", test.shorthand)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("ParseDraftShorthand(%q) = %q, want %q", test.shorthand, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGenerateFromYAML_DisallowUnevaluatedProperties(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:                 Draft2020,
+		DisallowUnevaluatedProperties: true,
+	})
+
+	yamlData := []byte(`
+image:
+  repository: nginx
+  tag: "1.25"
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	if schema.UnevaluatedProperties == nil || *schema.UnevaluatedProperties {
+		t.Error("expected root schema to have unevaluatedProperties: false")
+	}
+
+	imageSchema := schema.Properties["image"]
+	if imageSchema.UnevaluatedProperties == nil || *imageSchema.UnevaluatedProperties {
+		t.Error("expected nested object schema to have unevaluatedProperties: false")
+	}
+}
+
+func TestGenerateFromYAML_UnevaluatedPropertiesSkippedForDraft07(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:                 Draft07,
+		DisallowUnevaluatedProperties: true,
+	})
+
+	schema, err := generator.GenerateFromYAML(ctx, []byte("replicaCount: 1\n"))
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	if schema.UnevaluatedProperties != nil {
+		t.Error("expected draft-07 schema to leave unevaluatedProperties unset")
+	}
+}
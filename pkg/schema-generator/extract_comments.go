@@ -1,17 +1,69 @@
 package jsonschema
 
 import (
-	"bufio"
-	"bytes"
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
+	"strconv"
 	"strings"
+
+	"github.com/dc-tec/helm-schema-gen/pkg/logging"
+	"gopkg.in/yaml.v3"
 )
 
+// PathAnnotations holds structured "@tag value" overrides parsed out of a
+// YAML comment block by CommentExtractor (see ExtractFromYAML), keyed by
+// the same dotted path as the plain-text comments map. Only fields an
+// annotation actually set are non-zero; ApplyCommentsToSchema applies each
+// non-zero field over whatever inference produced.
+type PathAnnotations struct {
+	// Type overrides the inferred Schema.Type. A "@type a|b" tag becomes a
+	// []SchemaType; a single type becomes a bare SchemaType, matching the
+	// shapes Schema.Type already takes elsewhere.
+	Type any
+
+	Format               string
+	Enum                 []any
+	Minimum              *float64
+	Maximum              *float64
+	MinLength            *int
+	MaxLength            *int
+	Pattern              string
+	Required             bool
+	Deprecated           bool
+	Example              any
+	HasExample           bool
+	Default              any
+	HasDefault           bool
+	Title                string
+	Description          string
+	AdditionalProperties *bool
+}
+
+// isZero reports whether a has no overrides to apply.
+func (a PathAnnotations) isZero() bool {
+	return a.Type == nil && a.Format == "" && a.Enum == nil && a.Minimum == nil &&
+		a.Maximum == nil && a.MinLength == nil && a.MaxLength == nil && a.Pattern == "" &&
+		!a.Required && !a.Deprecated && !a.HasExample && !a.HasDefault &&
+		a.Title == "" && a.Description == "" && a.AdditionalProperties == nil
+}
+
+// annotationTags lists the recognized "@tag" names, used to warn about
+// unrecognized ones instead of silently folding them into the description.
+var annotationTags = map[string]bool{
+	"type": true, "format": true, "enum": true, "minimum": true, "maximum": true,
+	"minLength": true, "maxLength": true, "pattern": true, "required": true,
+	"deprecated": true, "example": true, "default": true, "title": true,
+	"description": true, "additionalProperties": true,
+}
+
 // CommentExtractor extracts comments from YAML files and associates them with paths
 type CommentExtractor struct {
 	// Map from YAML path to comment
 	comments map[string]string
+	// Map from YAML path to structured "@tag" annotations
+	annotations map[string]PathAnnotations
 	// Debug mode - print more info
 	Debug bool
 }
@@ -19,128 +71,166 @@ type CommentExtractor struct {
 // NewCommentExtractor creates a new comment extractor
 func NewCommentExtractor() *CommentExtractor {
 	return &CommentExtractor{
-		comments: make(map[string]string),
-		Debug:    false,
+		comments:    make(map[string]string),
+		annotations: make(map[string]PathAnnotations),
+		Debug:       false,
 	}
 }
 
-// ExtractFromYAML parses a YAML file and extracts comments
-func (e *CommentExtractor) ExtractFromYAML(yamlData []byte) {
-	scanner := bufio.NewScanner(bytes.NewReader(yamlData))
+// ExtractFromYAML parses a YAML file into a yaml.v3 node tree and walks it
+// recursively, extracting both free-text comments and structured "@tag
+// value" annotations (see PathAnnotations) embedded in them. An
+// unrecognized "@tag" is logged as a warning and dropped rather than
+// folded into the comment's plain text. Paths are built the same way
+// inferSchema builds Schema.HelmPath (see type_detection.go), including
+// "parent[N]" indices for sequence items, so comments on list entries
+// reach the corresponding Schema.Items sub-schema.
+func (e *CommentExtractor) ExtractFromYAML(ctx context.Context, yamlData []byte) {
+	logger := logging.WithComponent(ctx, "json-schema-generator")
 
-	var indentToPath = make(map[int][]string) // Map indentation levels to path components
-	var pendingComments string
-	var topLevelComment string
-	var lineIndents = []int{} // Track indentation levels for path management
+	var doc yaml.Node
+	if err := yaml.Unmarshal(yamlData, &doc); err != nil {
+		if e.Debug {
+			fmt.Fprintf(os.Stderr, "failed to parse YAML for comment extraction: %v\n", err)
+		}
+		return
+	}
+	if len(doc.Content) == 0 {
+		return
+	}
 
-	lineNum := 0
-	foundFirstKey := false
+	// The document node itself carries the file-level leading comment block,
+	// but only when a blank line separates it from the first key; otherwise
+	// yaml.v3 attaches it as that key's own head comment instead.
+	if doc.HeadComment != "" {
+		e.recordComment(ctx, logger, "", doc.HeadComment)
+	}
 
-	for scanner.Scan() {
-		lineNum++
-		line := scanner.Text()
+	root := doc.Content[0]
+	e.walkNode(ctx, logger, root, "")
 
-		// Skip empty lines
-		if strings.TrimSpace(line) == "" {
-			continue
+	// A comment block with no preceding blank line doubles as both the
+	// file-level description and the first key's own comment.
+	if _, ok := e.comments[""]; !ok && root.Kind == yaml.MappingNode && len(root.Content) >= 2 {
+		if comment, ok := e.comments[root.Content[0].Value]; ok {
+			e.comments[""] = comment
 		}
+	}
+}
 
-		// Check if this is a comment line
-		if strings.HasPrefix(strings.TrimSpace(line), "#") {
-			comment := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+// walkNode recurses into a mapping or sequence node, recording the comment
+// (and any annotations within it) attached to each child at its dotted path.
+func (e *CommentExtractor) walkNode(ctx context.Context, logger *slog.Logger, node *yaml.Node, path string) {
+	if node == nil {
+		return
+	}
 
-			// Some Helm charts use a special syntax like "# -- This is a description"
-			// to explicitly mark comments as descriptions
-			comment = strings.TrimPrefix(comment, "-- ")
-			comment = strings.TrimPrefix(comment, "--")
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+			valueNode := node.Content[i+1]
+			childPath := joinHelmPath(path, keyNode.Value)
 
-			// If we haven't found the first key yet, this might be a file-level comment
-			if !foundFirstKey {
-				if topLevelComment == "" {
-					topLevelComment = comment
-				} else {
-					topLevelComment += "\n" + comment
-				}
+			if comment := collectComment(keyNode, valueNode); comment != "" {
+				e.recordComment(ctx, logger, childPath, comment)
 			}
 
-			// Accumulate comment for the next field
-			if pendingComments == "" {
-				pendingComments = comment
-			} else {
-				pendingComments += "\n" + comment
+			e.walkNode(ctx, logger, valueNode, childPath)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+			if comment := collectComment(item); comment != "" {
+				e.recordComment(ctx, logger, itemPath, comment)
 			}
-			continue
+
+			e.walkNode(ctx, logger, item, itemPath)
 		}
+	}
+}
 
-		// If we have a key-value pair, process it
-		if strings.Contains(line, ":") {
-			foundFirstKey = true
+// joinHelmPath appends key to base using the same dotted convention as
+// inferSchema: the root path is "", and a top-level key's own path is just
+// the bare key (no leading dot).
+func joinHelmPath(base, key string) string {
+	if base == "" {
+		return key
+	}
+	return base + "." + key
+}
 
-			// Calculate indentation level
-			indent := len(line) - len(strings.TrimLeft(line, " "))
+// collectComment gathers the head, line, and foot comments off of one or
+// more yaml.Node (typically a mapping entry's key and value node, or a bare
+// sequence item), preferring head comments first since that's where Helm
+// values files conventionally document the next field.
+func collectComment(nodes ...*yaml.Node) string {
+	var head, line, foot []string
+	for _, n := range nodes {
+		if n == nil {
+			continue
+		}
+		if n.HeadComment != "" {
+			head = append(head, n.HeadComment)
+		}
+		if n.LineComment != "" {
+			line = append(line, n.LineComment)
+		}
+		if n.FootComment != "" {
+			foot = append(foot, n.FootComment)
+		}
+	}
+	parts := append(append(head, line...), foot...)
+	return strings.Join(parts, "\n")
+}
 
-			// Extract the key
-			parts := strings.SplitN(strings.TrimSpace(line), ":", 2)
-			key := strings.TrimSpace(parts[0])
+// recordComment splits a raw, possibly multi-line yaml.v3 comment block
+// (each line still carrying its leading "#") into plain description text
+// and structured "@tag" annotations, then stores whichever of the two is
+// non-empty for path.
+func (e *CommentExtractor) recordComment(ctx context.Context, logger *slog.Logger, path, raw string) {
+	var annotations PathAnnotations
+	var plainLines []string
 
-			// Update the path based on indentation
-			// First, find the correct parent level
-			var parentLevel int = -1
-			for i := len(lineIndents) - 1; i >= 0; i-- {
-				if lineIndents[i] < indent {
-					parentLevel = lineIndents[i]
-					break
-				}
-			}
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
 
-			// If we found a parent level, use its path as base
-			var currentPath []string
-			if parentLevel >= 0 {
-				currentPath = append([]string{}, indentToPath[parentLevel]...)
-			}
+		comment := strings.TrimSpace(strings.TrimPrefix(line, "#"))
 
-			// Add current key to path
-			currentPath = append(currentPath, key)
-
-			// Update indent tracking
-			found := false
-			for i, lvl := range lineIndents {
-				if lvl == indent {
-					found = true
-					// Replace existing path at this level
-					indentToPath[indent] = currentPath
-					// Truncate indentation levels to remove deeper levels
-					lineIndents = lineIndents[:i+1]
-					break
-				}
-			}
+		// Some Helm charts use a special syntax like "# -- This is a description"
+		// to explicitly mark comments as descriptions
+		comment = strings.TrimPrefix(comment, "-- ")
+		comment = strings.TrimPrefix(comment, "--")
 
-			if !found {
-				// New indentation level
-				lineIndents = append(lineIndents, indent)
-				indentToPath[indent] = currentPath
+		if tag, rest, ok := splitAnnotationTag(comment); ok {
+			if !annotationTags[tag] {
+				logger.WarnContext(ctx, "unrecognized comment annotation tag, ignoring", "tag", "@"+tag, "path", path)
+			} else {
+				applyAnnotationTag(&annotations, tag, rest)
 			}
+			continue
+		}
 
-			// Create dot-notation path
-			pathStr := strings.Join(currentPath, ".")
+		plainLines = append(plainLines, comment)
+	}
 
-			// If we have pending comments, associate them with this path
-			if pendingComments != "" {
-				if e.Debug {
-					fmt.Fprintf(os.Stderr, "Associated comment with path %s: %s\n", pathStr, pendingComments)
-				}
-				e.comments[pathStr] = pendingComments
-				pendingComments = ""
-			}
+	if len(plainLines) > 0 {
+		joined := strings.Join(plainLines, "\n")
+		if e.Debug {
+			fmt.Fprintf(os.Stderr, "Associated comment with path %s: %s\n", path, joined)
 		}
+		e.comments[path] = joined
 	}
 
-	// Store the top-level comment as a special entry if we found one
-	if topLevelComment != "" {
-		e.comments[""] = topLevelComment
+	if !annotations.isZero() {
 		if e.Debug {
-			fmt.Fprintf(os.Stderr, "Found top-level comment: %s\n", topLevelComment)
+			fmt.Fprintf(os.Stderr, "Associated annotations with path %s: %+v\n", path, annotations)
 		}
+		e.annotations[path] = annotations
 	}
 }
 
@@ -149,6 +239,129 @@ func (e *CommentExtractor) GetComment(path string) string {
 	return e.comments[path]
 }
 
+// GetAnnotations retrieves the structured "@tag" annotations for a given path.
+func (e *CommentExtractor) GetAnnotations(path string) PathAnnotations {
+	return e.annotations[path]
+}
+
+// splitAnnotationTag checks whether comment is a "@tag value" line (value is
+// optional, e.g. "@required" or "@deprecated" take none) and, if so, returns
+// the tag name and the remaining trimmed argument text.
+func splitAnnotationTag(comment string) (tag string, rest string, ok bool) {
+	if !strings.HasPrefix(comment, "@") {
+		return "", "", false
+	}
+	body := strings.TrimPrefix(comment, "@")
+	fields := strings.SplitN(body, " ", 2)
+	tag = strings.TrimSpace(fields[0])
+	if tag == "" {
+		return "", "", false
+	}
+	if len(fields) == 2 {
+		rest = strings.TrimSpace(fields[1])
+	}
+	return tag, rest, true
+}
+
+// applyAnnotationTag parses a single recognized "@tag value" line and merges
+// it into annotations.
+func applyAnnotationTag(annotations *PathAnnotations, tag, rest string) {
+	switch tag {
+	case "type":
+		if strings.Contains(rest, "|") {
+			parts := strings.Split(rest, "|")
+			types := make([]SchemaType, 0, len(parts))
+			for _, p := range parts {
+				types = append(types, SchemaType(strings.TrimSpace(p)))
+			}
+			annotations.Type = types
+		} else {
+			annotations.Type = SchemaType(rest)
+		}
+	case "format":
+		annotations.Format = rest
+	case "enum":
+		annotations.Enum = parseAnnotationEnum(rest)
+	case "minimum":
+		annotations.Minimum = parseAnnotationFloat(rest)
+	case "maximum":
+		annotations.Maximum = parseAnnotationFloat(rest)
+	case "minLength":
+		annotations.MinLength = parseAnnotationInt(rest)
+	case "maxLength":
+		annotations.MaxLength = parseAnnotationInt(rest)
+	case "pattern":
+		annotations.Pattern = rest
+	case "required":
+		annotations.Required = true
+	case "deprecated":
+		annotations.Deprecated = true
+	case "example":
+		annotations.Example = parseAnnotationScalar(rest)
+		annotations.HasExample = true
+	case "default":
+		annotations.Default = parseAnnotationScalar(rest)
+		annotations.HasDefault = true
+	case "title":
+		annotations.Title = rest
+	case "description":
+		annotations.Description = rest
+	case "additionalProperties":
+		v := strings.EqualFold(rest, "true")
+		annotations.AdditionalProperties = &v
+	}
+}
+
+// parseAnnotationScalar converts a tag argument to a bool, int, float64, or
+// string, trying each in turn - the same loosely-typed coercion Helm values
+// files themselves use.
+func parseAnnotationScalar(raw string) any {
+	if b, err := strconv.ParseBool(raw); err == nil {
+		return b
+	}
+	if i, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return f
+	}
+	return raw
+}
+
+// parseAnnotationEnum parses a "@enum [a, b, c]" or "@enum a, b, c" argument
+// into a slice of scalar values.
+func parseAnnotationEnum(raw string) []any {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimPrefix(raw, "[")
+	raw = strings.TrimSuffix(raw, "]")
+	parts := strings.Split(raw, ",")
+	values := make([]any, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		values = append(values, parseAnnotationScalar(p))
+	}
+	return values
+}
+
+func parseAnnotationFloat(raw string) *float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
+
+func parseAnnotationInt(raw string) *int {
+	i, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		return nil
+	}
+	return &i
+}
+
 // PrintAllComments prints all extracted comments to stderr for debugging
 func (e *CommentExtractor) PrintAllComments() {
 	fmt.Fprintf(os.Stderr, "=== Extracted Comments ===\n")
@@ -158,8 +371,16 @@ func (e *CommentExtractor) PrintAllComments() {
 	fmt.Fprintf(os.Stderr, "=== End of Comments ===\n")
 }
 
-// ApplyCommentsToSchema adds descriptions to schema based on YAML comments
+// ApplyCommentsToSchema adds descriptions to schema based on YAML comments,
+// then overlays any structured "@tag" annotations found for the same paths
+// (see PathAnnotations). A child's "@required" annotation is applied to its
+// parent's Required list via addRequired, since "required" is a property of
+// the parent object schema, not of the child itself.
 func (e *CommentExtractor) ApplyCommentsToSchema(schema *Schema) {
+	e.applyCommentsToSchema(schema, nil, "")
+}
+
+func (e *CommentExtractor) applyCommentsToSchema(schema *Schema, parent *Schema, key string) {
 	if schema == nil {
 		return
 	}
@@ -176,15 +397,88 @@ func (e *CommentExtractor) ApplyCommentsToSchema(schema *Schema) {
 		}
 	}
 
+	if annotations, ok := e.annotations[schema.HelmPath]; ok {
+		e.applyAnnotations(schema, annotations)
+		if annotations.Required && parent != nil {
+			addRequired(parent, key)
+		}
+	}
+
 	// Recursively apply to properties
 	if schema.Properties != nil {
-		for _, propSchema := range schema.Properties {
-			e.ApplyCommentsToSchema(propSchema)
+		for propKey, propSchema := range schema.Properties {
+			e.applyCommentsToSchema(propSchema, schema, propKey)
 		}
 	}
 
 	// Apply to array items
 	if schema.Items != nil {
-		e.ApplyCommentsToSchema(schema.Items)
+		e.applyCommentsToSchema(schema.Items, nil, "")
+	}
+
+	// Apply to positional (tuple) array items
+	for _, item := range schema.TuplePrefixItems {
+		e.applyCommentsToSchema(item, nil, "")
+	}
+	for _, item := range schema.TupleLegacyItems {
+		e.applyCommentsToSchema(item, nil, "")
+	}
+
+	// Apply to hoisted definitions (see (*Generator).deduplicateSchemas), so
+	// descriptions still reach subschemas that were moved out of their
+	// original position and replaced with a $ref.
+	for _, def := range schema.Definitions {
+		e.applyCommentsToSchema(def, nil, "")
+	}
+	for _, def := range schema.Defs {
+		e.applyCommentsToSchema(def, nil, "")
+	}
+}
+
+// applyAnnotations overlays the non-zero fields of annotations onto schema.
+// Explicit @description/@title annotations take priority over (override)
+// the plain free-text comment-derived Description.
+func (e *CommentExtractor) applyAnnotations(schema *Schema, annotations PathAnnotations) {
+	if annotations.Type != nil {
+		schema.Type = annotations.Type
+	}
+	if annotations.Format != "" {
+		schema.Format = annotations.Format
+	}
+	if annotations.Enum != nil {
+		schema.Enum = annotations.Enum
+	}
+	if annotations.Minimum != nil {
+		schema.Minimum = annotations.Minimum
+	}
+	if annotations.Maximum != nil {
+		schema.Maximum = annotations.Maximum
+	}
+	if annotations.MinLength != nil {
+		schema.MinLength = annotations.MinLength
+	}
+	if annotations.MaxLength != nil {
+		schema.MaxLength = annotations.MaxLength
+	}
+	if annotations.Pattern != "" {
+		schema.Pattern = annotations.Pattern
+	}
+	if annotations.Deprecated {
+		schema.Deprecated = true
+	}
+	if annotations.HasExample {
+		schema.Examples = append(schema.Examples, annotations.Example)
+	}
+	if annotations.HasDefault {
+		schema.Default = annotations.Default
+	}
+	if annotations.Title != "" {
+		schema.Title = annotations.Title
+	}
+	if annotations.Description != "" {
+		schema.Description = annotations.Description
+	}
+	if annotations.AdditionalProperties != nil {
+		schema.AdditionalProperties = annotations.AdditionalProperties
 	}
 }
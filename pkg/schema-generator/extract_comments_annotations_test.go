@@ -0,0 +1,149 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestExtractFromYAML_AnnotationTagsPopulatePathAnnotations(t *testing.T) {
+	yamlData := `# @type string
+# @format email
+# @minLength 3
+# @maxLength 64
+# @pattern ^.+@.+$
+# @deprecated
+# @title Contact email
+# @description Email address used for notifications
+# @example admin@example.com
+# @default admin@example.com
+email: admin@example.com
+
+# @enum [small, medium, large]
+size: medium
+
+# @minimum 1
+# @maximum 10
+count: 5
+
+# @required
+name: app
+
+# @additionalProperties false
+config:
+  debug: true
+`
+
+	extractor := NewCommentExtractor()
+	extractor.ExtractFromYAML(context.Background(), []byte(yamlData))
+
+	email := extractor.GetAnnotations("email")
+	if email.Type != SchemaType("string") {
+		t.Errorf("expected @type to set Type to string, got %v", email.Type)
+	}
+	if email.Format != "email" {
+		t.Errorf("expected @format email, got %q", email.Format)
+	}
+	if email.MinLength == nil || *email.MinLength != 3 {
+		t.Errorf("expected @minLength 3, got %v", email.MinLength)
+	}
+	if email.MaxLength == nil || *email.MaxLength != 64 {
+		t.Errorf("expected @maxLength 64, got %v", email.MaxLength)
+	}
+	if email.Pattern != "^.+@.+$" {
+		t.Errorf("expected @pattern, got %q", email.Pattern)
+	}
+	if !email.Deprecated {
+		t.Error("expected @deprecated to be set")
+	}
+	if email.Title != "Contact email" {
+		t.Errorf("expected @title, got %q", email.Title)
+	}
+	if email.Description != "Email address used for notifications" {
+		t.Errorf("expected @description, got %q", email.Description)
+	}
+	if !email.HasExample || email.Example != "admin@example.com" {
+		t.Errorf("expected @example, got %v", email.Example)
+	}
+	if !email.HasDefault || email.Default != "admin@example.com" {
+		t.Errorf("expected @default, got %v", email.Default)
+	}
+
+	size := extractor.GetAnnotations("size")
+	if len(size.Enum) != 3 || size.Enum[0] != "small" {
+		t.Errorf("expected @enum to parse 3 values, got %v", size.Enum)
+	}
+
+	count := extractor.GetAnnotations("count")
+	if count.Minimum == nil || *count.Minimum != 1 {
+		t.Errorf("expected @minimum 1, got %v", count.Minimum)
+	}
+	if count.Maximum == nil || *count.Maximum != 10 {
+		t.Errorf("expected @maximum 10, got %v", count.Maximum)
+	}
+
+	name := extractor.GetAnnotations("name")
+	if !name.Required {
+		t.Error("expected @required to be set")
+	}
+
+	config := extractor.GetAnnotations("config")
+	if config.AdditionalProperties == nil || *config.AdditionalProperties != false {
+		t.Errorf("expected @additionalProperties false, got %v", config.AdditionalProperties)
+	}
+}
+
+func TestExtractFromYAML_UnrecognizedTagDoesNotPolluteDescription(t *testing.T) {
+	yamlData := `# @bogus something
+# Regular comment
+key: value
+`
+	extractor := NewCommentExtractor()
+	extractor.ExtractFromYAML(context.Background(), []byte(yamlData))
+
+	if comment := extractor.GetComment("key"); comment != "Regular comment" {
+		t.Errorf("expected unrecognized tag to be dropped rather than folded into the comment, got %q", comment)
+	}
+}
+
+func TestApplyCommentsToSchema_RequiredAnnotationAppliesToParent(t *testing.T) {
+	schema := &Schema{
+		Type:     TypeObject,
+		HelmPath: "",
+		Properties: map[string]*Schema{
+			"name": {Type: TypeString, HelmPath: "name"},
+		},
+	}
+
+	extractor := NewCommentExtractor()
+	extractor.annotations["name"] = PathAnnotations{Required: true}
+
+	extractor.ApplyCommentsToSchema(schema)
+
+	found := false
+	for _, r := range schema.Required {
+		if r == "name" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected @required on name to add it to the parent's Required list, got %v", schema.Required)
+	}
+}
+
+func TestApplyCommentsToSchema_DescriptionAnnotationOverridesPlainComment(t *testing.T) {
+	schema := &Schema{
+		Type:        TypeString,
+		HelmPath:    "email",
+		Description: "",
+	}
+
+	extractor := NewCommentExtractor()
+	extractor.comments["email"] = "plain comment"
+	extractor.annotations["email"] = PathAnnotations{Description: "annotated description"}
+
+	extractor.ApplyCommentsToSchema(schema)
+
+	if schema.Description != "annotated description" {
+		t.Errorf("expected @description to override the plain comment, got %q", schema.Description)
+	}
+}
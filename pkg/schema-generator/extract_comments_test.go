@@ -1,6 +1,7 @@
 package jsonschema
 
 import (
+	"context"
 	"strings"
 	"testing"
 )
@@ -42,7 +43,7 @@ myObject:
 	// Extract comments
 	extractor := NewCommentExtractor()
 	extractor.Debug = true
-	extractor.ExtractFromYAML([]byte(yamlData))
+	extractor.ExtractFromYAML(context.Background(), []byte(yamlData))
 
 	// Print all comments for debugging
 	for path, comment := range extractor.comments {
@@ -77,3 +78,31 @@ myObject:
 		}
 	}
 }
+
+func TestCommentExtraction_SequenceItemsAndLastKey(t *testing.T) {
+	yamlData := `list:
+  # Comment for first item
+  - name: a
+  # Comment for second item
+  - name: b
+
+lastKey: z
+# Trailing comment with nothing after it
+`
+
+	extractor := NewCommentExtractor()
+	extractor.ExtractFromYAML(context.Background(), []byte(yamlData))
+
+	if comment := extractor.GetComment("list[0]"); !strings.HasPrefix(comment, "Comment for first item") {
+		t.Errorf("expected a comment on the first sequence item, got %q", comment)
+	}
+	if comment := extractor.GetComment("list[0].name"); comment != "" {
+		t.Errorf("expected the item-level comment to attach to the item itself, not its name field, got %q", comment)
+	}
+	if comment := extractor.GetComment("list[1]"); !strings.HasPrefix(comment, "Comment for second item") {
+		t.Errorf("expected a comment on the second sequence item, got %q", comment)
+	}
+	if comment := extractor.GetComment("lastKey"); !strings.Contains(comment, "Trailing comment") {
+		t.Errorf("expected the trailing foot comment after the last key to still be captured, got %q", comment)
+	}
+}
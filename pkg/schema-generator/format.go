@@ -0,0 +1,230 @@
+package jsonschema
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FormatChecker recognizes whether a value conforms to a named JSON Schema
+// "format" (see Schema.Format). Implementations are registered with
+// RegisterFormatChecker and used, via compileAndValidate, to enforce format
+// during values.yaml validation. Format auto-detection during schema
+// inference is a separate, generator-scoped concern - see FormatDetector
+// and Generator.RegisterFormat.
+type FormatChecker interface {
+	IsFormat(input any) bool
+}
+
+// FormatCheckerFunc adapts a plain function to a FormatChecker, the same way
+// http.HandlerFunc adapts a function to a http.Handler.
+type FormatCheckerFunc func(input any) bool
+
+// IsFormat calls f(input).
+func (f FormatCheckerFunc) IsFormat(input any) bool {
+	return f(input)
+}
+
+var (
+	formatCheckersMu sync.RWMutex
+	formatCheckers   = make(map[string]FormatChecker)
+
+	// formatDetectionOrder controls the priority used when inferring a
+	// format from an example string value: the first registered checker in
+	// this order that matches wins. Built-ins are ordered most-specific to
+	// least-specific so that, e.g., a bare "8080" is detected as a port
+	// rather than a quantity, and "250m" as a quantity rather than a
+	// duration (250 minutes) - both are otherwise valid parses of the same
+	// string.
+	formatDetectionOrder = []string{
+		"ipv4", "ipv6", "semver", "port", "quantity", "duration", "k8s-name", "hostname",
+	}
+)
+
+// RegisterFormatChecker registers checker under name, making it available
+// both for values.yaml validation (see compileAndValidate) and, if name
+// appears in formatDetectionOrder, for format auto-detection during schema
+// inference. Registering a name that's already registered replaces it.
+func RegisterFormatChecker(name string, checker FormatChecker) {
+	formatCheckersMu.Lock()
+	defer formatCheckersMu.Unlock()
+	formatCheckers[name] = checker
+}
+
+// lookupFormatChecker returns the checker registered for name, if any.
+func lookupFormatChecker(name string) (FormatChecker, bool) {
+	formatCheckersMu.RLock()
+	defer formatCheckersMu.RUnlock()
+	checker, ok := formatCheckers[name]
+	return checker, ok
+}
+
+// registeredFormatCheckers snapshots the registry as the
+// map[string]func(any) bool shape the underlying JSON Schema validator
+// expects (see compileAndValidate).
+func registeredFormatCheckers() map[string]func(any) bool {
+	formatCheckersMu.RLock()
+	defer formatCheckersMu.RUnlock()
+
+	funcs := make(map[string]func(any) bool, len(formatCheckers))
+	for name, checker := range formatCheckers {
+		funcs[name] = checker.IsFormat
+	}
+	return funcs
+}
+
+// detectRegisteredFormat returns the name of the first registered format in
+// formatDetectionOrder that matches s, or "" if none do. Callers should try
+// more established format checks (date, date-time, email, uri) first.
+func detectRegisteredFormat(s string) string {
+	for _, name := range formatDetectionOrder {
+		checker, ok := lookupFormatChecker(name)
+		if ok && checker.IsFormat(s) {
+			return name
+		}
+	}
+	return ""
+}
+
+func init() {
+	RegisterFormatChecker("duration", FormatCheckerFunc(isDurationFormat))
+	RegisterFormatChecker("quantity", FormatCheckerFunc(isQuantityFormat))
+	RegisterFormatChecker("hostname", FormatCheckerFunc(isHostnameFormat))
+	RegisterFormatChecker("email", FormatCheckerFunc(isEmailFormat))
+	RegisterFormatChecker("uri", FormatCheckerFunc(isURIFormat))
+	RegisterFormatChecker("ipv4", FormatCheckerFunc(isIPv4Format))
+	RegisterFormatChecker("ipv6", FormatCheckerFunc(isIPv6Format))
+	RegisterFormatChecker("port", FormatCheckerFunc(isPortFormat))
+	RegisterFormatChecker("k8s-name", FormatCheckerFunc(isK8sNameFormat))
+	RegisterFormatChecker("semver", FormatCheckerFunc(isSemverFormat))
+}
+
+// asString returns input as a string, treating any non-string input as
+// trivially valid - JSON Schema format keywords only constrain strings.
+func asString(input any) (string, bool) {
+	s, ok := input.(string)
+	return s, ok
+}
+
+// isDurationFormat reports whether input parses as a Go duration, e.g.
+// "30s", "5m", "1h30m".
+func isDurationFormat(input any) bool {
+	s, ok := asString(input)
+	if !ok {
+		return true
+	}
+	_, err := time.ParseDuration(s)
+	return err == nil
+}
+
+// quantityPattern matches Kubernetes resource.Quantity strings such as
+// "500m", "2Gi", "1.5", "128Ki". See
+// https://pkg.go.dev/k8s.io/apimachinery/pkg/api/resource#Quantity.
+var quantityPattern = regexp.MustCompile(`^[+-]?(\d+(\.\d+)?|\.\d+)(Ei|Pi|Ti|Gi|Mi|Ki|E|P|T|G|M|k|m|n|u)?$`)
+
+// isQuantityFormat reports whether input is a Kubernetes resource.Quantity,
+// e.g. "500m" (CPU millicores) or "2Gi" (memory).
+func isQuantityFormat(input any) bool {
+	s, ok := asString(input)
+	if !ok {
+		return true
+	}
+	return quantityPattern.MatchString(s)
+}
+
+// hostnamePattern matches RFC 1123 hostnames: dot-separated labels of
+// alphanumerics and hyphens, neither starting nor ending with a hyphen.
+var hostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*$`)
+
+// isHostnameFormat reports whether input is a valid RFC 1123 hostname.
+func isHostnameFormat(input any) bool {
+	s, ok := asString(input)
+	if !ok {
+		return true
+	}
+	return len(s) > 0 && len(s) <= 253 && hostnamePattern.MatchString(s)
+}
+
+// isEmailFormat reports whether input appears to be an email address.
+// Unlike the simple heuristic used during inference (see isEmail), this
+// checker is used for validate-time enforcement of an explicit
+// format: email schema.
+func isEmailFormat(input any) bool {
+	s, ok := asString(input)
+	if !ok {
+		return true
+	}
+	return isEmail(s)
+}
+
+// isURIFormat reports whether input appears to be a URI. See isURIFormat's
+// inference-time counterpart, isURI.
+func isURIFormat(input any) bool {
+	s, ok := asString(input)
+	if !ok {
+		return true
+	}
+	return isURI(s)
+}
+
+// isIPv4Format reports whether input is a dotted-decimal IPv4 address.
+func isIPv4Format(input any) bool {
+	s, ok := asString(input)
+	if !ok {
+		return true
+	}
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() != nil && strings.Contains(s, ".")
+}
+
+// isIPv6Format reports whether input is an IPv6 address.
+func isIPv6Format(input any) bool {
+	s, ok := asString(input)
+	if !ok {
+		return true
+	}
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() == nil
+}
+
+// isPortFormat reports whether input is a valid TCP/UDP port number
+// (1-65535).
+func isPortFormat(input any) bool {
+	s, ok := asString(input)
+	if !ok {
+		return true
+	}
+	port, err := strconv.Atoi(s)
+	return err == nil && port >= 1 && port <= 65535
+}
+
+// k8sNamePattern matches a single RFC 1123 DNS label, as used for
+// Kubernetes object names: lowercase alphanumerics and hyphens, neither
+// starting nor ending with a hyphen.
+var k8sNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// isK8sNameFormat reports whether input is a valid Kubernetes object name
+// (an RFC 1123 DNS label).
+func isK8sNameFormat(input any) bool {
+	s, ok := asString(input)
+	if !ok {
+		return true
+	}
+	return len(s) > 0 && len(s) <= 253 && k8sNamePattern.MatchString(s)
+}
+
+// semverPattern matches a semantic version, e.g. "1.2.3" or
+// "1.2.3-rc.1+build.5". See https://semver.org.
+var semverPattern = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z-.]+)?(\+[0-9A-Za-z-.]+)?$`)
+
+// isSemverFormat reports whether input is a semantic version string.
+func isSemverFormat(input any) bool {
+	s, ok := asString(input)
+	if !ok {
+		return true
+	}
+	return semverPattern.MatchString(s)
+}
@@ -0,0 +1,148 @@
+package jsonschema
+
+import (
+	"regexp"
+	"strings"
+)
+
+// FormatDetector infers whether a string value conforms to a named JSON
+// Schema "format" during generation, letting inferSchema tag a string leaf
+// with a format value (see Schema.Format) beyond the handful inferSchema
+// checks directly (date, date-time, email, uri). Unlike FormatChecker,
+// which validates a value against an already-known format at validate
+// time, a FormatDetector is only ever asked "does this look like you" - it
+// has no say over format enforcement.
+type FormatDetector interface {
+	// Name identifies the detector, e.g. for GeneratorOptions.EnabledFormats.
+	Name() string
+
+	// Detect reports whether value looks like this detector's format.
+	Detect(value string) bool
+}
+
+// formatDetectorFunc adapts a name and a plain function to a FormatDetector.
+type formatDetectorFunc struct {
+	name string
+	fn   func(string) bool
+}
+
+func (d formatDetectorFunc) Name() string             { return d.name }
+func (d formatDetectorFunc) Detect(value string) bool { return d.fn(value) }
+
+// newFormatDetector builds a FormatDetector from a name and a plain
+// detection function.
+func newFormatDetector(name string, fn func(string) bool) FormatDetector {
+	return formatDetectorFunc{name: name, fn: fn}
+}
+
+// defaultFormatDetectors returns the built-in format detectors, tried in
+// this order; the first match wins. The order is most-specific first, as
+// with formatDetectionOrder in format.go, except that this list has no
+// "port" detector: a bare "8080" is reported as a quantity here. Quantity
+// runs before duration, so "250m" is a quantity rather than a 250-minute
+// duration.
+func defaultFormatDetectors() []FormatDetector {
+	return []FormatDetector{
+		newFormatDetector("uuid", isUUIDFormat),
+		newFormatDetector("ipv4", func(s string) bool { return isIPv4Format(s) }),
+		newFormatDetector("ipv6", func(s string) bool { return isIPv6Format(s) }),
+		newFormatDetector("semver", func(s string) bool { return isSemverFormat(s) }),
+		newFormatDetector("quantity", func(s string) bool { return isQuantityFormat(s) }),
+		newFormatDetector("duration", func(s string) bool { return isDurationFormat(s) }),
+		newFormatDetector("cron", isCronFormat),
+		newFormatDetector("hostname", func(s string) bool { return isHostnameFormat(s) }),
+		newFormatDetector("regex", isRegexFormat),
+	}
+}
+
+// RegisterFormat appends a custom FormatDetector to g's registry, tried
+// after the built-ins. Use this to teach schema inference a format value
+// that isn't covered by the defaults.
+func (g *Generator) RegisterFormat(d FormatDetector) {
+	g.formatDetectors = append(g.formatDetectors, d)
+}
+
+// enabledFormatDetectors returns the built-in format detectors plus any
+// registered with RegisterFormat, restricted to Options.EnabledFormats when
+// it's non-empty.
+func (g *Generator) enabledFormatDetectors() []FormatDetector {
+	all := append(append([]FormatDetector{}, defaultFormatDetectors()...), g.formatDetectors...)
+	if len(g.Options.EnabledFormats) == 0 {
+		return all
+	}
+
+	enabled := make(map[string]bool, len(g.Options.EnabledFormats))
+	for _, name := range g.Options.EnabledFormats {
+		enabled[name] = true
+	}
+
+	detectors := make([]FormatDetector, 0, len(all))
+	for _, d := range all {
+		if enabled[d.Name()] {
+			detectors = append(detectors, d)
+		}
+	}
+	return detectors
+}
+
+// detectFormat returns the name of the first enabled FormatDetector that
+// matches value, or "" if none do.
+func (g *Generator) detectFormat(value string) string {
+	for _, d := range g.enabledFormatDetectors() {
+		if d.Detect(value) {
+			return d.Name()
+		}
+	}
+	return ""
+}
+
+// uuidPattern matches a standard 8-4-4-4-12 hex UUID, any version/variant.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// isUUIDFormat reports whether value is a UUID.
+func isUUIDFormat(value string) bool {
+	return uuidPattern.MatchString(value)
+}
+
+// cronFieldPattern matches a single standard cron field: digits, names, and
+// the usual list/range/step syntax (*, -, ,, /).
+var cronFieldPattern = regexp.MustCompile(`^[0-9A-Za-z*,/-]+$`)
+
+// isCronFormat reports whether value looks like a 5- or 6-field cron
+// expression, e.g. "0 9 * * 1-5" or "*/5 * * * * *".
+func isCronFormat(value string) bool {
+	fields := strings.Fields(value)
+	if len(fields) != 5 && len(fields) != 6 {
+		return false
+	}
+	for _, field := range fields {
+		if !cronFieldPattern.MatchString(field) {
+			return false
+		}
+	}
+	return true
+}
+
+// regexStructurePattern matches constructs that are distinctively
+// regex-shaped rather than merely regexp.Compile-able: a character class, a
+// \d/\w/\s-style escape, a {m,n} quantifier, or alternation. Plain prose,
+// versions ("nginx:1.21.0"), hostnames, and parenthesized asides
+// ("Enabled (true/false)") don't incidentally produce any of these, unlike
+// a single stray '.', '(', or '?'.
+var regexStructurePattern = regexp.MustCompile(`\[.+\]|\\[dDwWsSbB]|\{\d+(,\d*)?\}|\|`)
+
+// isRegexFormat reports whether value is both a syntactically valid regular
+// expression and actually looks like one: anchored at the start ("^") or
+// end ("$"), or containing a construct in regexStructurePattern. Requiring
+// this instead of just "contains any regex metacharacter" is what keeps
+// ordinary sentences, versions, and hostnames - which routinely contain a
+// lone '.', '(', or '?' and still compile as a regexp - from being
+// misdetected as "format": "regex".
+func isRegexFormat(value string) bool {
+	anchored := strings.HasPrefix(value, "^") || strings.HasSuffix(value, "$")
+	if !anchored && !regexStructurePattern.MatchString(value) {
+		return false
+	}
+	_, err := regexp.Compile(value)
+	return err == nil
+}
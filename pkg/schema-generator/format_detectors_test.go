@@ -0,0 +1,109 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDefaultFormatDetectors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"uuid", "550e8400-e29b-41d4-a716-446655440000", "uuid"},
+		{"ipv4", "10.0.0.1", "ipv4"},
+		{"ipv6", "2001:db8::1", "ipv6"},
+		{"semver", "1.2.3-rc.1", "semver"},
+		{"quantity", "512Mi", "quantity"},
+		{"duration", "30s", "duration"},
+		{"cron", "0 9 * * 1-5", "cron"},
+		{"hostname", "api.example.com", "hostname"},
+		{"regex", "^[a-z]+$", "regex"},
+		{"plain string", "just some text", ""},
+	}
+
+	g := NewGenerator(DefaultOptions())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := g.detectFormat(tc.value); got != tc.want {
+				t.Errorf("detectFormat(%q) = %q, want %q", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegexFormatAvoidsFalsePositives(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"sentence with a period", "Set to true to enable."},
+		{"image tag", "nginx:1.21.0"},
+		{"trailing-dot hostname", "my-app.example.com."},
+		{"parenthesized aside", "Enabled (true/false)"},
+	}
+
+	g := NewGenerator(DefaultOptions())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := g.detectFormat(tc.value); got == "regex" {
+				t.Errorf("detectFormat(%q) = %q, want anything but \"regex\"", tc.value, got)
+			}
+		})
+	}
+}
+
+func TestDetectFormatOrderQuantityBeforeDuration(t *testing.T) {
+	g := NewGenerator(DefaultOptions())
+	if got := g.detectFormat("250m"); got != "quantity" {
+		t.Errorf("expected \"250m\" to be detected as quantity before duration, got %q", got)
+	}
+}
+
+func TestRegisterFormatCustomDetector(t *testing.T) {
+	g := NewGenerator(DefaultOptions())
+	g.RegisterFormat(newFormatDetector("acme-id", func(s string) bool {
+		return len(s) == 9 && s[:5] == "ACME_"
+	}))
+
+	if got := g.detectFormat("ACME_1234"); got != "acme-id" {
+		t.Errorf("expected the custom detector to win, got %q", got)
+	}
+}
+
+func TestEnabledFormatsRestrictsDetectors(t *testing.T) {
+	g := NewGenerator(GeneratorOptions{
+		SchemaVersion:  Draft07,
+		EnabledFormats: []string{"ipv4"},
+	})
+
+	if got := g.detectFormat("10.0.0.1"); got != "ipv4" {
+		t.Errorf("expected ipv4 to still be enabled, got %q", got)
+	}
+	if got := g.detectFormat("550e8400-e29b-41d4-a716-446655440000"); got != "" {
+		t.Errorf("expected uuid detection to be disabled by EnabledFormats, got %q", got)
+	}
+}
+
+func TestInferSchemaDetectsFormatOnStringLeaf(t *testing.T) {
+	ctx := context.Background()
+	g := NewGenerator(DefaultOptions())
+
+	data := map[string]any{
+		"clusterIP": "10.0.0.1",
+		"requestID": "550e8400-e29b-41d4-a716-446655440000",
+	}
+
+	schema, err := g.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	if format := schema.Properties["clusterIP"].Format; format != "ipv4" {
+		t.Errorf("expected clusterIP to be detected as ipv4, got %q", format)
+	}
+	if format := schema.Properties["requestID"].Format; format != "uuid" {
+		t.Errorf("expected requestID to be detected as uuid, got %q", format)
+	}
+}
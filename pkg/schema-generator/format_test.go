@@ -0,0 +1,119 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBuiltinFormatCheckers(t *testing.T) {
+	tests := []struct {
+		format string
+		value  string
+		want   bool
+	}{
+		{"duration", "30s", true},
+		{"duration", "1h30m", true},
+		{"duration", "not-a-duration", false},
+		{"quantity", "500m", true},
+		{"quantity", "2Gi", true},
+		{"quantity", "not-a-quantity", false},
+		{"hostname", "my-service.default.svc", true},
+		{"hostname", "_invalid_", false},
+		{"ipv4", "192.168.1.1", true},
+		{"ipv4", "::1", false},
+		{"ipv6", "::1", true},
+		{"ipv6", "192.168.1.1", false},
+		{"port", "8080", true},
+		{"port", "70000", false},
+		{"k8s-name", "my-app", true},
+		{"k8s-name", "My_App", false},
+		{"semver", "1.2.3", true},
+		{"semver", "1.2.3-rc.1+build.5", true},
+		{"semver", "v1.2.3", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format+"/"+test.value, func(t *testing.T) {
+			checker, ok := lookupFormatChecker(test.format)
+			if !ok {
+				t.Fatalf("no checker registered for format %q", test.format)
+			}
+			if got := checker.IsFormat(test.value); got != test.want {
+				t.Errorf("IsFormat(%q) for format %q = %v, want %v", test.value, test.format, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRegisterFormatChecker(t *testing.T) {
+	RegisterFormatChecker("always-true-test-format", FormatCheckerFunc(func(any) bool { return true }))
+
+	checker, ok := lookupFormatChecker("always-true-test-format")
+	if !ok {
+		t.Fatal("expected custom format checker to be registered")
+	}
+	if !checker.IsFormat("anything") {
+		t.Error("expected custom checker to report a match")
+	}
+}
+
+func TestDetectRegisteredFormat(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"250m", "quantity"},
+		{"2Gi", "quantity"},
+		{"30s", "duration"},
+		{"8080", "port"},
+		{"192.168.1.1", "ipv4"},
+		{"::1", "ipv6"},
+		{"1.2.3", "semver"},
+		{"my-app", "k8s-name"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			if got := detectRegisteredFormat(test.value); got != test.want {
+				t.Errorf("detectRegisteredFormat(%q) = %q, want %q", test.value, got, test.want)
+			}
+		})
+	}
+}
+
+func TestInferSchema_AutoDetectsQuantityFormat(t *testing.T) {
+	ctx := context.Background()
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+	schema, err := generator.inferSchema(ctx, "250m", "foo.bar")
+	if err != nil {
+		t.Fatalf("inferSchema failed: %v", err)
+	}
+	if schema.Format != "quantity" {
+		t.Errorf("expected format quantity, got %q", schema.Format)
+	}
+}
+
+func TestValidate_EnforcesRegisteredFormat(t *testing.T) {
+	ctx := context.Background()
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+	schema := &Schema{
+		Schema: Draft07,
+		Type:   TypeObject,
+		Properties: map[string]*Schema{
+			"port": {Type: TypeString, Format: "port"},
+		},
+	}
+
+	errs, err := generator.Validate(ctx, schema, []byte("port: \"70000\"\n"))
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 validation error for an out-of-range port, got %d: %+v", len(errs), errs)
+	}
+	if errs[0].Keyword != "format" {
+		t.Errorf("expected keyword format, got %q", errs[0].Keyword)
+	}
+}
@@ -0,0 +1,557 @@
+package jsonschema
+
+// Recognizer identifies a schema node that follows a well-known Helm chart
+// configuration idiom - a container image block, a resources block, a
+// liveness/readiness probe, and so on - and replaces it with a schema
+// hand-tuned for that idiom instead of the generic shape inference would
+// otherwise produce.
+type Recognizer interface {
+	// Name identifies the recognizer, e.g. for GeneratorOptions.DisabledRecognizers
+	// and the --disable-recognizers CLI flag.
+	Name() string
+
+	// Match reports whether schema looks like this recognizer's shape.
+	Match(schema *Schema) bool
+
+	// Build returns a specialized schema for the node found at path.
+	// original is the schema the generator actually inferred there, so
+	// callers can copy forward user-observed values - see
+	// preserveObservedValues.
+	Build(path string, original *Schema) *Schema
+}
+
+// defaultRecognizers returns the built-in Helm-idiom recognizers, tried in
+// this order; the first match wins.
+func defaultRecognizers() []Recognizer {
+	return []Recognizer{
+		imageRecognizer{},
+		resourcesRecognizer{},
+		probeRecognizer{},
+		serviceRecognizer{},
+		ingressRecognizer{},
+		persistenceRecognizer{},
+		podSecurityContextRecognizer{},
+		autoscalingRecognizer{},
+		serviceAccountRecognizer{},
+		affinityTolerationsRecognizer{},
+	}
+}
+
+// RegisterRecognizer appends a custom recognizer to g's registry, tried
+// after the built-ins. Use this to teach SpecializeSchemaForHelm a chart
+// idiom of your own that isn't covered by the defaults.
+func (g *Generator) RegisterRecognizer(r Recognizer) {
+	g.recognizers = append(g.recognizers, r)
+}
+
+// enabledRecognizers returns the built-in recognizers plus any registered
+// with RegisterRecognizer, minus whichever names appear in
+// Options.DisabledRecognizers.
+func (g *Generator) enabledRecognizers() []Recognizer {
+	all := append(append([]Recognizer{}, defaultRecognizers()...), g.recognizers...)
+	if len(g.Options.DisabledRecognizers) == 0 {
+		return all
+	}
+
+	disabled := make(map[string]bool, len(g.Options.DisabledRecognizers))
+	for _, name := range g.Options.DisabledRecognizers {
+		disabled[name] = true
+	}
+
+	enabled := make([]Recognizer, 0, len(all))
+	for _, r := range all {
+		if !disabled[r.Name()] {
+			enabled = append(enabled, r)
+		}
+	}
+	return enabled
+}
+
+// SpecializeSchemaForHelm walks schema, replacing any descendant object
+// subschema that matches an enabled Recognizer with that recognizer's
+// specialized template, while preserving values actually observed in the
+// source data (see preserveObservedValues). The root schema itself is never
+// replaced, only its descendants.
+func (g *Generator) SpecializeSchemaForHelm(schema *Schema) *Schema {
+	if schema == nil {
+		return schema
+	}
+
+	recognizers := g.enabledRecognizers()
+	for _, prop := range schema.Properties {
+		specializeNode(prop, recognizers)
+	}
+	if schema.Items != nil {
+		specializeNode(schema.Items, recognizers)
+	}
+
+	return schema
+}
+
+// specializeNode tries every recognizer against schema in turn, overwriting
+// its fields in place with the first match's Build output, then recurses
+// into whatever children result - the node's own children if nothing
+// matched, or the specialized template's children otherwise.
+func specializeNode(schema *Schema, recognizers []Recognizer) {
+	if schema == nil {
+		return
+	}
+
+	for _, r := range recognizers {
+		if !r.Match(schema) {
+			continue
+		}
+		specialized := r.Build(schema.HelmPath, schema)
+		preserveObservedValues(specialized, schema)
+		*schema = *specialized
+		break
+	}
+
+	for _, prop := range schema.Properties {
+		specializeNode(prop, recognizers)
+	}
+	if schema.Items != nil {
+		specializeNode(schema.Items, recognizers)
+	}
+}
+
+// preserveObservedValues copies Default/Examples from original and its
+// properties onto the matching property of specialized (by key), so a
+// recognizer's canned template doesn't clobber a value actually observed in
+// the user's values.yaml.
+func preserveObservedValues(specialized, original *Schema) {
+	if specialized == nil || original == nil {
+		return
+	}
+
+	if original.Default != nil {
+		specialized.Default = original.Default
+	}
+	if len(original.Examples) > 0 {
+		specialized.Examples = original.Examples
+	}
+
+	for key, prop := range specialized.Properties {
+		if observed, ok := original.Properties[key]; ok {
+			preserveObservedValues(prop, observed)
+		}
+	}
+}
+
+// hasAnyProperty reports whether schema is an object schema with at least
+// one of the given property names.
+func hasAnyProperty(schema *Schema, names ...string) bool {
+	if schema == nil || len(schema.Properties) == 0 {
+		return false
+	}
+	for _, name := range names {
+		if _, ok := schema.Properties[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAllProperties reports whether schema is an object schema with every
+// one of the given property names.
+func hasAllProperties(schema *Schema, names ...string) bool {
+	if schema == nil || len(schema.Properties) == 0 {
+		return false
+	}
+	for _, name := range names {
+		if _, ok := schema.Properties[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// imageRecognizer matches a container image block: a repository plus a tag.
+type imageRecognizer struct{}
+
+func (imageRecognizer) Name() string { return "image" }
+
+func (imageRecognizer) Match(schema *Schema) bool {
+	return hasAllProperties(schema, "repository", "tag")
+}
+
+func (imageRecognizer) Build(path string, _ *Schema) *Schema {
+	return &Schema{
+		Type:        TypeObject,
+		Description: "Container image configuration",
+		HelmPath:    path,
+		Properties: map[string]*Schema{
+			"repository": {
+				Type:        TypeString,
+				Description: "Container image repository",
+			},
+			"tag": {
+				Type:        TypeString,
+				Description: "Container image tag",
+				Default:     "latest",
+			},
+			"pullPolicy": {
+				Type:        TypeString,
+				Description: "Image pull policy",
+				Enum:        []any{"Always", "IfNotPresent", "Never"},
+				Default:     "IfNotPresent",
+			},
+		},
+		Required: []string{"repository"},
+	}
+}
+
+// resourcesRecognizer matches a Kubernetes resources block: limits and/or
+// requests.
+type resourcesRecognizer struct{}
+
+func (resourcesRecognizer) Name() string { return "resources" }
+
+func (resourcesRecognizer) Match(schema *Schema) bool {
+	return hasAnyProperty(schema, "limits", "requests")
+}
+
+func (resourcesRecognizer) Build(path string, _ *Schema) *Schema {
+	quantity := func(description string) *Schema {
+		return &Schema{
+			Type:        TypeObject,
+			Description: description,
+			Properties: map[string]*Schema{
+				"cpu": {
+					Type:        TypeString,
+					Description: "CPU " + description,
+					Examples:    []any{"100m", "0.1"},
+				},
+				"memory": {
+					Type:        TypeString,
+					Description: "Memory " + description,
+					Examples:    []any{"128Mi", "1Gi"},
+				},
+			},
+		}
+	}
+
+	return &Schema{
+		Type:        TypeObject,
+		Description: "CPU/Memory resource requirements",
+		HelmPath:    path,
+		Properties: map[string]*Schema{
+			"limits":   quantity("limit"),
+			"requests": quantity("request"),
+		},
+	}
+}
+
+// probeRecognizer matches a container liveness/readiness/startup probe: one
+// of the probe handlers alongside initialDelaySeconds.
+type probeRecognizer struct{}
+
+func (probeRecognizer) Name() string { return "probe" }
+
+func (probeRecognizer) Match(schema *Schema) bool {
+	if !hasAnyProperty(schema, "initialDelaySeconds") {
+		return false
+	}
+	return hasAnyProperty(schema, "httpGet", "tcpSocket", "exec")
+}
+
+func (probeRecognizer) Build(path string, _ *Schema) *Schema {
+	return &Schema{
+		Type:        TypeObject,
+		Description: "Container probe configuration",
+		HelmPath:    path,
+		Properties: map[string]*Schema{
+			"httpGet": {
+				Type:        TypeObject,
+				Description: "HTTP GET probe",
+				Properties: map[string]*Schema{
+					"path": {Type: TypeString, Description: "HTTP path to probe"},
+					"port": {Type: []SchemaType{TypeInteger, TypeString}, Description: "Port to probe"},
+				},
+			},
+			"tcpSocket": {
+				Type:        TypeObject,
+				Description: "TCP socket probe",
+				Properties: map[string]*Schema{
+					"port": {Type: []SchemaType{TypeInteger, TypeString}, Description: "Port to probe"},
+				},
+			},
+			"exec": {
+				Type:        TypeObject,
+				Description: "Exec probe",
+				Properties: map[string]*Schema{
+					"command": {Type: TypeArray, Items: &Schema{Type: TypeString}, Description: "Command to run inside the container"},
+				},
+			},
+			"initialDelaySeconds": {Type: TypeInteger, Description: "Seconds after container start before the probe is initiated", Default: 0},
+			"periodSeconds":       {Type: TypeInteger, Description: "How often to perform the probe", Default: 10},
+			"timeoutSeconds":      {Type: TypeInteger, Description: "Seconds after which the probe times out", Default: 1},
+			"successThreshold":    {Type: TypeInteger, Description: "Minimum consecutive successes for the probe to be considered successful", Default: 1},
+			"failureThreshold":    {Type: TypeInteger, Description: "Minimum consecutive failures for the probe to be considered failed", Default: 3},
+		},
+	}
+}
+
+// serviceRecognizer matches a Kubernetes Service block: a ClusterIP /
+// NodePort / LoadBalancer type alongside a port or ports field.
+type serviceRecognizer struct{}
+
+func (serviceRecognizer) Name() string { return "service" }
+
+func (serviceRecognizer) Match(schema *Schema) bool {
+	if !hasAnyProperty(schema, "port", "ports") {
+		return false
+	}
+	typeProp, ok := schema.Properties["type"]
+	if !ok {
+		return false
+	}
+	for _, v := range typeProp.Enum {
+		if s, ok := v.(string); ok && (s == "ClusterIP" || s == "NodePort" || s == "LoadBalancer") {
+			return true
+		}
+	}
+	if s, ok := typeProp.Default.(string); ok {
+		return s == "ClusterIP" || s == "NodePort" || s == "LoadBalancer"
+	}
+	return false
+}
+
+func (serviceRecognizer) Build(path string, original *Schema) *Schema {
+	specialized := &Schema{
+		Type:        TypeObject,
+		Description: "Kubernetes Service configuration",
+		HelmPath:    path,
+		Properties: map[string]*Schema{
+			"type": {
+				Type:        TypeString,
+				Description: "Service type",
+				Enum:        []any{"ClusterIP", "NodePort", "LoadBalancer"},
+				Default:     "ClusterIP",
+			},
+		},
+		Required: []string{"type"},
+	}
+
+	if _, ok := original.Properties["port"]; ok {
+		specialized.Properties["port"] = &Schema{Type: TypeInteger, Description: "Service port"}
+	}
+	if _, ok := original.Properties["ports"]; ok {
+		specialized.Properties["ports"] = &Schema{Type: TypeArray, Description: "Additional named service ports"}
+	}
+
+	return specialized
+}
+
+// ingressRecognizer matches a Kubernetes Ingress block: a hosts list with
+// host/paths entries, optionally alongside tls entries with a secretName.
+type ingressRecognizer struct{}
+
+func (ingressRecognizer) Name() string { return "ingress" }
+
+func (ingressRecognizer) Match(schema *Schema) bool {
+	hosts, ok := schema.Properties["hosts"]
+	if !ok || hosts.Items == nil {
+		return false
+	}
+	return hasAnyProperty(hosts.Items, "host", "paths")
+}
+
+func (ingressRecognizer) Build(path string, original *Schema) *Schema {
+	specialized := &Schema{
+		Type:        TypeObject,
+		Description: "Kubernetes Ingress configuration",
+		HelmPath:    path,
+		Properties: map[string]*Schema{
+			"enabled": {Type: TypeBoolean, Description: "Whether to create an Ingress resource", Default: false},
+			"hosts": {
+				Type:        TypeArray,
+				Description: "Ingress hosts",
+				Items: &Schema{
+					Type: TypeObject,
+					Properties: map[string]*Schema{
+						"host": {Type: TypeString, Description: "Hostname to match"},
+						"paths": {
+							Type:        TypeArray,
+							Description: "Paths routed to this host",
+							Items: &Schema{
+								Type: TypeObject,
+								Properties: map[string]*Schema{
+									"path":     {Type: TypeString, Description: "Path to match", Default: "/"},
+									"pathType": {Type: TypeString, Description: "Ingress path type", Default: "ImplementationSpecific"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if _, ok := original.Properties["tls"]; ok {
+		specialized.Properties["tls"] = &Schema{
+			Type:        TypeArray,
+			Description: "TLS configuration for this Ingress",
+			Items: &Schema{
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"secretName": {Type: TypeString, Description: "Name of the Secret holding the TLS certificate"},
+					"hosts":      {Type: TypeArray, Description: "Hosts covered by this certificate", Items: &Schema{Type: TypeString}},
+				},
+			},
+		}
+	}
+
+	return specialized
+}
+
+// persistenceRecognizer matches a PersistentVolumeClaim block: accessModes,
+// storageClass, and/or size.
+type persistenceRecognizer struct{}
+
+func (persistenceRecognizer) Name() string { return "persistence" }
+
+func (persistenceRecognizer) Match(schema *Schema) bool {
+	return hasAnyProperty(schema, "accessModes", "storageClass", "size")
+}
+
+func (persistenceRecognizer) Build(path string, _ *Schema) *Schema {
+	return &Schema{
+		Type:        TypeObject,
+		Description: "Persistent volume configuration",
+		HelmPath:    path,
+		Properties: map[string]*Schema{
+			"enabled": {Type: TypeBoolean, Description: "Whether to create a PersistentVolumeClaim", Default: true},
+			"accessModes": {
+				Type:        TypeArray,
+				Description: "PersistentVolumeClaim access modes",
+				Items:       &Schema{Type: TypeString, Enum: []any{"ReadWriteOnce", "ReadOnlyMany", "ReadWriteMany"}},
+				Default:     []any{"ReadWriteOnce"},
+			},
+			"storageClass": {Type: TypeString, Description: "StorageClass to request (empty string uses the cluster default)"},
+			"size":         {Type: TypeString, Description: "Size of the persistent volume", Examples: []any{"8Gi", "100Mi"}},
+		},
+	}
+}
+
+// podSecurityContextRecognizer matches a pod security context block:
+// runAsUser and/or fsGroup.
+type podSecurityContextRecognizer struct{}
+
+func (podSecurityContextRecognizer) Name() string { return "podSecurityContext" }
+
+func (podSecurityContextRecognizer) Match(schema *Schema) bool {
+	return hasAnyProperty(schema, "runAsUser", "fsGroup")
+}
+
+func (podSecurityContextRecognizer) Build(path string, _ *Schema) *Schema {
+	return &Schema{
+		Type:        TypeObject,
+		Description: "Pod-level security context",
+		HelmPath:    path,
+		Properties: map[string]*Schema{
+			"runAsUser":    {Type: []SchemaType{TypeInteger, TypeNull}, Description: "UID the container's process runs as"},
+			"runAsGroup":   {Type: []SchemaType{TypeInteger, TypeNull}, Description: "GID the container's process runs as"},
+			"runAsNonRoot": {Type: TypeBoolean, Description: "Require that the container not run as root"},
+			"fsGroup":      {Type: []SchemaType{TypeInteger, TypeNull}, Description: "Supplemental group applied to mounted volumes"},
+		},
+	}
+}
+
+// autoscalingRecognizer matches a HorizontalPodAutoscaler block:
+// minReplicas, maxReplicas, and a CPU utilization target.
+type autoscalingRecognizer struct{}
+
+func (autoscalingRecognizer) Name() string { return "autoscaling" }
+
+func (autoscalingRecognizer) Match(schema *Schema) bool {
+	return hasAllProperties(schema, "minReplicas", "maxReplicas") ||
+		hasAnyProperty(schema, "targetCPUUtilizationPercentage")
+}
+
+func (autoscalingRecognizer) Build(path string, original *Schema) *Schema {
+	specialized := &Schema{
+		Type:        TypeObject,
+		Description: "HorizontalPodAutoscaler configuration",
+		HelmPath:    path,
+		Properties: map[string]*Schema{
+			"enabled":     {Type: TypeBoolean, Description: "Whether to create a HorizontalPodAutoscaler", Default: false},
+			"minReplicas": {Type: TypeInteger, Description: "Minimum number of replicas", Default: 1},
+			"maxReplicas": {Type: TypeInteger, Description: "Maximum number of replicas", Default: 100},
+		},
+	}
+
+	if _, ok := original.Properties["targetCPUUtilizationPercentage"]; ok {
+		specialized.Properties["targetCPUUtilizationPercentage"] = &Schema{Type: TypeInteger, Description: "Target average CPU utilization percentage", Default: 80}
+	}
+	if _, ok := original.Properties["targetMemoryUtilizationPercentage"]; ok {
+		specialized.Properties["targetMemoryUtilizationPercentage"] = &Schema{Type: TypeInteger, Description: "Target average memory utilization percentage"}
+	}
+
+	return specialized
+}
+
+// serviceAccountRecognizer matches a ServiceAccount block: create, name,
+// and/or annotations.
+type serviceAccountRecognizer struct{}
+
+func (serviceAccountRecognizer) Name() string { return "serviceAccount" }
+
+func (serviceAccountRecognizer) Match(schema *Schema) bool {
+	return hasAllProperties(schema, "create", "name") || hasAllProperties(schema, "create", "annotations")
+}
+
+func (serviceAccountRecognizer) Build(path string, _ *Schema) *Schema {
+	return &Schema{
+		Type:        TypeObject,
+		Description: "ServiceAccount configuration",
+		HelmPath:    path,
+		Properties: map[string]*Schema{
+			"create": {Type: TypeBoolean, Description: "Whether to create a ServiceAccount", Default: true},
+			"annotations": {
+				Type:        []SchemaType{TypeObject, TypeString},
+				Description: "Annotations to add to the ServiceAccount",
+			},
+			"name": {Type: TypeString, Description: "Name of the ServiceAccount to use (generated from the chart's fullname if not set)"},
+		},
+	}
+}
+
+// affinityTolerationsRecognizer matches a scheduling block combining node
+// affinity and tolerations, the two most common Helm scheduling knobs.
+type affinityTolerationsRecognizer struct{}
+
+func (affinityTolerationsRecognizer) Name() string { return "affinityTolerations" }
+
+func (affinityTolerationsRecognizer) Match(schema *Schema) bool {
+	return hasAllProperties(schema, "affinity", "tolerations")
+}
+
+func (affinityTolerationsRecognizer) Build(path string, original *Schema) *Schema {
+	specialized := &Schema{
+		Type:        TypeObject,
+		Description: "Pod scheduling configuration",
+		HelmPath:    path,
+		Properties: map[string]*Schema{
+			"affinity": {
+				Type:        []SchemaType{TypeObject, TypeString},
+				Description: "Node/pod affinity and anti-affinity rules",
+			},
+			"tolerations": {
+				Type:        []SchemaType{TypeArray, TypeString},
+				Description: "Tolerations for pod scheduling onto tainted nodes",
+				Items:       &Schema{Type: TypeObject},
+			},
+			"nodeSelector": {
+				Type:        []SchemaType{TypeObject, TypeString},
+				Description: "Node labels required for pod scheduling",
+			},
+		},
+	}
+
+	if _, ok := original.Properties["nodeSelector"]; !ok {
+		delete(specialized.Properties, "nodeSelector")
+	}
+
+	return specialized
+}
@@ -0,0 +1,249 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGenerateFromMap_SpecializeForHelmRecognizesImageAndResources(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:     Draft07,
+		SpecializeForHelm: true,
+	})
+
+	data := map[string]any{
+		"image": map[string]any{
+			"repository": "nginx",
+			"tag":        "1.25",
+		},
+		"resources": map[string]any{
+			"limits": map[string]any{"cpu": "500m"},
+		},
+	}
+
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	image := schema.Properties["image"]
+	if _, ok := image.Properties["pullPolicy"]; !ok {
+		t.Errorf("expected the image recognizer's template to add pullPolicy, got %+v", image.Properties)
+	}
+	if image.Properties["repository"].Default != nil {
+		t.Errorf("observed repository had no Default, expected the template's empty Default to survive, got %v", image.Properties["repository"].Default)
+	}
+
+	resources := schema.Properties["resources"]
+	if _, ok := resources.Properties["requests"]; !ok {
+		t.Errorf("expected the resources recognizer's template to add requests alongside limits, got %+v", resources.Properties)
+	}
+}
+
+func TestGenerateFromMap_SpecializeForHelmPreservesObservedDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:     Draft07,
+		SpecializeForHelm: true,
+		IncludeExamples:   true,
+	})
+
+	data := map[string]any{
+		"image": map[string]any{
+			"repository": "myorg/myapp",
+			"tag":        "v2.3.4",
+		},
+	}
+
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	tag := schema.Properties["image"].Properties["tag"]
+	if got, ok := tag.Examples[0].(string); !ok || got != "v2.3.4" {
+		t.Errorf("expected the observed tag example to survive specialization instead of being clobbered, got %+v", tag.Examples)
+	}
+}
+
+func TestGenerateFromMap_SpecializeForHelmDisabledByDefault(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+	data := map[string]any{
+		"image": map[string]any{
+			"repository": "nginx",
+			"tag":        "1.25",
+		},
+	}
+
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	if _, ok := schema.Properties["image"].Properties["pullPolicy"]; ok {
+		t.Error("expected no specialization to happen when SpecializeForHelm is unset")
+	}
+}
+
+func TestGenerateFromMap_DisabledRecognizersSkipsNamedRecognizer(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:       Draft07,
+		SpecializeForHelm:   true,
+		DisabledRecognizers: []string{"image"},
+	})
+
+	data := map[string]any{
+		"image": map[string]any{
+			"repository": "nginx",
+			"tag":        "1.25",
+		},
+		"resources": map[string]any{
+			"limits": map[string]any{"cpu": "500m"},
+		},
+	}
+
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	if _, ok := schema.Properties["image"].Properties["pullPolicy"]; ok {
+		t.Error("expected the image recognizer to be disabled")
+	}
+	if _, ok := schema.Properties["resources"].Properties["requests"]; !ok {
+		t.Error("expected the resources recognizer to still run since only 'image' was disabled")
+	}
+}
+
+func TestGenerateFromMap_SpecializeForHelmRecognizesProbeServiceIngressAndMore(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:     Draft07,
+		SpecializeForHelm: true,
+	})
+
+	data := map[string]any{
+		"livenessProbe": map[string]any{
+			"httpGet":             map[string]any{"path": "/healthz", "port": 8080},
+			"initialDelaySeconds": 10,
+		},
+		"service": map[string]any{
+			"type": "ClusterIP",
+			"port": 80,
+		},
+		"ingress": map[string]any{
+			"hosts": []any{
+				map[string]any{"host": "example.com", "paths": []any{"/"}},
+			},
+			"tls": []any{
+				map[string]any{"secretName": "example-tls"},
+			},
+		},
+		"persistence": map[string]any{
+			"accessModes": []any{"ReadWriteOnce"},
+			"size":        "8Gi",
+		},
+		"podSecurityContext": map[string]any{
+			"runAsUser": 1000,
+			"fsGroup":   2000,
+		},
+		"autoscaling": map[string]any{
+			"minReplicas":                    1,
+			"maxReplicas":                    10,
+			"targetCPUUtilizationPercentage": 80,
+		},
+		"serviceAccount": map[string]any{
+			"create": true,
+			"name":   "my-sa",
+		},
+		"scheduling": map[string]any{
+			"affinity":    map[string]any{},
+			"tolerations": []any{},
+		},
+	}
+
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	if _, ok := schema.Properties["livenessProbe"].Properties["periodSeconds"]; !ok {
+		t.Error("expected the probe recognizer to fire on livenessProbe")
+	}
+	if _, ok := schema.Properties["service"].Properties["type"]; !ok {
+		t.Error("expected the service recognizer to fire on service")
+	}
+	if _, ok := schema.Properties["ingress"].Properties["tls"]; !ok {
+		t.Error("expected the ingress recognizer to preserve the observed tls block")
+	}
+	if _, ok := schema.Properties["persistence"].Properties["storageClass"]; !ok {
+		t.Error("expected the persistence recognizer to fire on persistence")
+	}
+	if _, ok := schema.Properties["podSecurityContext"].Properties["runAsNonRoot"]; !ok {
+		t.Error("expected the podSecurityContext recognizer to fire")
+	}
+	if _, ok := schema.Properties["autoscaling"].Properties["targetCPUUtilizationPercentage"]; !ok {
+		t.Error("expected the autoscaling recognizer to fire")
+	}
+	if _, ok := schema.Properties["serviceAccount"].Properties["annotations"]; !ok {
+		t.Error("expected the serviceAccount recognizer to fire")
+	}
+	if _, ok := schema.Properties["scheduling"].Properties["nodeSelector"]; ok {
+		t.Error("expected nodeSelector to be omitted since it wasn't observed in the source data")
+	}
+}
+
+func TestRegisterRecognizer_CustomRecognizerRunsAfterBuiltins(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:     Draft07,
+		SpecializeForHelm: true,
+	})
+	generator.RegisterRecognizer(fakeNetworkPolicyRecognizer{})
+
+	data := map[string]any{
+		"networkPolicy": map[string]any{
+			"enabled": true,
+		},
+	}
+
+	schema, err := generator.GenerateFromMap(ctx, data)
+	if err != nil {
+		t.Fatalf("GenerateFromMap failed: %v", err)
+	}
+
+	if schema.Properties["networkPolicy"].Description != "custom network policy block" {
+		t.Errorf("expected the custom recognizer to fire, got description %q", schema.Properties["networkPolicy"].Description)
+	}
+}
+
+// fakeNetworkPolicyRecognizer is a minimal custom Recognizer used to verify
+// RegisterRecognizer wires a caller-supplied recognizer into the registry.
+type fakeNetworkPolicyRecognizer struct{}
+
+func (fakeNetworkPolicyRecognizer) Name() string { return "networkPolicy" }
+
+func (fakeNetworkPolicyRecognizer) Match(schema *Schema) bool {
+	return hasAnyProperty(schema, "enabled") && len(schema.Properties) == 1
+}
+
+func (fakeNetworkPolicyRecognizer) Build(path string, _ *Schema) *Schema {
+	return &Schema{
+		Type:        TypeObject,
+		Description: "custom network policy block",
+		HelmPath:    path,
+		Properties: map[string]*Schema{
+			"enabled": {Type: TypeBoolean},
+		},
+	}
+}
@@ -0,0 +1,139 @@
+package jsonschema
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
+
+	"github.com/dc-tec/helm-schema-gen/pkg/logging"
+)
+
+// GenerateFromYAMLIncremental behaves like GenerateFromYAML, but caches one
+// entry per top-level key instead of one entry for the whole document: a
+// values.yaml where only the "image" block changed still serves every other
+// top-level key's schema straight from cache, rather than regenerating the
+// whole file as GenerateFromYAMLCached would. This is the payoff for
+// mono-repos regenerating schemas for many charts in CI, where a given
+// chart's values.yaml usually only has a handful of keys change between
+// runs.
+//
+// Entries are stored under cache.Dir/<chart-hash>/<key>-<subtree-hash>.json,
+// where chart-hash identifies this document's top-level shape (its sorted
+// key names) so unrelated documents don't collide, and subtree-hash covers
+// that key's value plus the generator options, so either changing
+// invalidates just that one entry.
+func (g *Generator) GenerateFromYAMLIncremental(ctx context.Context, yamlData []byte, cache *SchemaCache) (*Schema, error) {
+	logger := logging.WithComponent(ctx, "json-schema-generator")
+
+	var interpolated []interpolationResult
+	if g.Options.InterpolateEnvVars {
+		substituted, results, err := interpolateEnvVars(yamlData, g.Options.EnvProvider)
+		if err != nil {
+			return nil, fmt.Errorf("failed to interpolate environment variables: %w", err)
+		}
+		yamlData = substituted
+		interpolated = results
+	}
+
+	dataMap, err := parseYAMLDocuments(yamlData, g.Options.ConcatSequencesOnMerge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
+	}
+
+	optionsHash, err := hashGeneratorOptions(g.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash generator options: %w", err)
+	}
+	chartDir := filepath.Join(cache.Dir, chartHash(dataMap))
+
+	rootSchema := g.newRootSchema()
+	for key, value := range dataMap {
+		propSchema, err := g.generatePropertySchema(ctx, logger, cache, chartDir, optionsHash, key, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to infer schema for property '%s': %w", key, err)
+		}
+		g.addProperty(rootSchema, key, value, propSchema)
+	}
+	g.finalizeRootSchema(rootSchema)
+
+	applyInterpolationHints(rootSchema, interpolated)
+
+	if g.Options.ExtractDescriptions {
+		commentExtractor := NewCommentExtractor()
+		commentExtractor.Debug = g.Options.Debug
+		commentExtractor.ExtractFromYAML(ctx, yamlData)
+
+		if topComment := commentExtractor.GetComment(""); topComment != "" && rootSchema.Description == "" {
+			rootSchema.Description = topComment
+		}
+		commentExtractor.ApplyCommentsToSchema(rootSchema)
+	}
+
+	logger.InfoContext(ctx, "incremental schema generation completed", "hits", cache.Hits, "misses", cache.Misses)
+	return rootSchema, nil
+}
+
+// generatePropertySchema returns key's schema, reusing a cached one when
+// value and the generator options hash to an entry already on disk, and
+// writing a fresh one back to cache on a miss.
+func (g *Generator) generatePropertySchema(ctx context.Context, logger *slog.Logger, cache *SchemaCache, chartDir, optionsHash, key string, value any) (*Schema, error) {
+	subHash, err := subtreeHash(value, optionsHash)
+	if err != nil {
+		logger.WarnContext(ctx, "failed to hash subtree, skipping cache for this property", "key", key, "error", err)
+		return g.inferSchema(ctx, value, key)
+	}
+	entryPath := filepath.Join(chartDir, key+"-"+subHash+".json")
+
+	if schema, ok := readCacheEntry(ctx, logger, entryPath, cache.MaxAge); ok {
+		cache.Hits++
+		return schema, nil
+	}
+	cache.Misses++
+
+	schema, err := g.inferSchema(ctx, value, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeCacheEntry(chartDir, entryPath, schema); err != nil {
+		logger.WarnContext(ctx, "failed to write incremental cache entry", "key", key, "error", err)
+	}
+
+	return schema, nil
+}
+
+// chartHash identifies a document by its top-level key names, so that
+// unrelated documents don't share a cache subdirectory. Renaming a
+// top-level key moves a chart to a new, cold subdirectory; changing only a
+// key's value does not, which is the property that makes the per-subtree
+// cache worthwhile.
+func chartHash(dataMap map[string]any) string {
+	keys := make([]string, 0, len(dataMap))
+	for key := range dataMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	sum := sha256.New()
+	for _, key := range keys {
+		sum.Write([]byte(key))
+		sum.Write([]byte{0})
+	}
+	return hex.EncodeToString(sum.Sum(nil)[:8])
+}
+
+// subtreeHash hashes a single top-level key's value together with the
+// generator options that will process it.
+func subtreeHash(value any, optionsHash string) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode subtree: %w", err)
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]) + "-" + optionsHash, nil
+}
@@ -0,0 +1,59 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGenerateFromYAMLIncremental_CachesPerTopLevelKey(t *testing.T) {
+	ctx := context.Background()
+	cache := NewSchemaCache(t.TempDir())
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+	yamlData := []byte("replicaCount: 1\nimage:\n  repository: nginx\n")
+
+	schema, err := generator.GenerateFromYAMLIncremental(ctx, yamlData, cache)
+	if err != nil {
+		t.Fatalf("GenerateFromYAMLIncremental failed: %v", err)
+	}
+	if cache.Misses != 2 || cache.Hits != 0 {
+		t.Errorf("expected 2 misses (one per top-level key) and 0 hits, got misses=%d hits=%d", cache.Misses, cache.Hits)
+	}
+	if _, ok := schema.Properties["replicaCount"]; !ok {
+		t.Errorf("expected replicaCount in the generated schema, got %+v", schema.Properties)
+	}
+
+	second, err := generator.GenerateFromYAMLIncremental(ctx, yamlData, cache)
+	if err != nil {
+		t.Fatalf("GenerateFromYAMLIncremental failed: %v", err)
+	}
+	if cache.Hits != 2 {
+		t.Errorf("expected both top-level keys to hit on an unchanged document, got hits=%d", cache.Hits)
+	}
+	if _, ok := second.Properties["image"]; !ok {
+		t.Errorf("expected image in the cached schema, got %+v", second.Properties)
+	}
+}
+
+func TestGenerateFromYAMLIncremental_OnlyDirtyKeyMisses(t *testing.T) {
+	ctx := context.Background()
+	cache := NewSchemaCache(t.TempDir())
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+	first := []byte("replicaCount: 1\nimage:\n  repository: nginx\n")
+	if _, err := generator.GenerateFromYAMLIncremental(ctx, first, cache); err != nil {
+		t.Fatalf("GenerateFromYAMLIncremental failed: %v", err)
+	}
+
+	changed := []byte("replicaCount: 1\nimage:\n  repository: alpine\n")
+	schema, err := generator.GenerateFromYAMLIncremental(ctx, changed, cache)
+	if err != nil {
+		t.Fatalf("GenerateFromYAMLIncremental failed: %v", err)
+	}
+	if cache.Hits != 1 || cache.Misses != 3 {
+		t.Errorf("expected the unchanged key to hit and the changed key to miss, got hits=%d misses=%d", cache.Hits, cache.Misses)
+	}
+	if _, ok := schema.Properties["image"]; !ok {
+		t.Errorf("expected image in the regenerated schema, got %+v", schema.Properties)
+	}
+}
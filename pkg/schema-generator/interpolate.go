@@ -0,0 +1,296 @@
+package jsonschema
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// EnvProvider looks up the value of an environment variable by name,
+// mirroring os.LookupEnv's signature so it can be swapped out in tests
+// without touching the process environment.
+type EnvProvider interface {
+	Lookup(name string) (string, bool)
+}
+
+// osEnvProvider is the default EnvProvider, backed by os.LookupEnv.
+type osEnvProvider struct{}
+
+func (osEnvProvider) Lookup(name string) (string, bool) {
+	return os.LookupEnv(name)
+}
+
+// envTokenPattern matches "${name}", "${name:-default}", and
+// "${name:?errMessage}" interpolation tokens.
+var envTokenPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*)|:\?([^}]*))?\}`)
+
+// blockScalarIndicatorPattern matches a YAML block scalar header - "|", ">",
+// with an optional chomping indicator ("-"/"+") and/or explicit indentation
+// indicator digit, optionally followed by a comment - the form that starts
+// a literal/folded block whose body must be passed through as-is rather
+// than parsed as further "key: value" lines.
+var blockScalarIndicatorPattern = regexp.MustCompile(`^[|>][+-]?[0-9]?\s*(#.*)?$`)
+
+// interpolationResult records, for a single YAML path, the raw token text
+// that was substituted there - used to add an examples hint to the
+// generated schema marking the field as templated.
+type interpolationResult struct {
+	path      string
+	rawTokens []string
+}
+
+// interpolateEnvVars walks yamlData line by line, substituting
+// "${name}"/"${name:-default}"/"${name:?errMessage}" tokens (and "$$"
+// escapes) from env, and returns the substituted document plus the set of
+// YAML paths that had at least one token replaced. It returns an error,
+// including the offending line number, for an unterminated "${" or a
+// "${name:?errMessage}" whose variable is unset. A "$$" escape becomes "$"
+// only on a line that also holds a "${...}" token; on any other line it is
+// left as written.
+func interpolateEnvVars(yamlData []byte, env EnvProvider) ([]byte, []interpolationResult, error) {
+	if env == nil {
+		env = osEnvProvider{}
+	}
+
+	lines := strings.Split(string(yamlData), "\n")
+
+	var indentToPath = make(map[int][]string)
+	var lineIndents []int
+
+	results := make(map[string]*interpolationResult)
+	var order []string
+
+	recordTokens := func(path string, tokens []string) {
+		if len(tokens) == 0 {
+			return
+		}
+		result, ok := results[path]
+		if !ok {
+			result = &interpolationResult{path: path}
+			results[path] = result
+			order = append(order, path)
+		}
+		result.rawTokens = append(result.rawTokens, tokens...)
+	}
+
+	var inBlockScalar bool
+	var blockScalarIndent int
+	var blockScalarPath string
+
+	for i, line := range lines {
+		lineNum := i + 1
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if inBlockScalar {
+			if trimmed == "" {
+				// A blank line inside (or immediately following) the block
+				// doesn't by itself tell us whether the block has ended, so
+				// leave it untouched and keep waiting for the next
+				// non-blank line to decide.
+				continue
+			}
+			if indent > blockScalarIndent {
+				// Still inside the block body: substitute tokens in place
+				// without any of interpolateValue's quoting/type-inference
+				// logic, since this text is literal and must come back out
+				// byte-for-byte aside from the substitution itself.
+				substituted, tokens, err := substituteTokens(line, env, lineNum)
+				if err != nil {
+					return nil, nil, err
+				}
+				if len(tokens) > 0 {
+					lines[i] = substituted
+					recordTokens(blockScalarPath, tokens)
+				}
+				continue
+			}
+			// Dedented back to (or past) the key that opened the block:
+			// the block has ended, so fall through and parse this line
+			// normally.
+			inBlockScalar = false
+		}
+
+		if trimmed == "---" {
+			indentToPath = make(map[int][]string)
+			lineIndents = nil
+			continue
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || !strings.Contains(line, ":") {
+			continue
+		}
+
+		parts := strings.SplitN(trimmed, ":", 2)
+		key := strings.TrimSpace(parts[0])
+
+		var parentLevel = -1
+		for j := len(lineIndents) - 1; j >= 0; j-- {
+			if lineIndents[j] < indent {
+				parentLevel = lineIndents[j]
+				break
+			}
+		}
+
+		var currentPath []string
+		if parentLevel >= 0 {
+			currentPath = append([]string{}, indentToPath[parentLevel]...)
+		}
+		currentPath = append(currentPath, key)
+		path := strings.Join(currentPath, ".")
+
+		found := false
+		for j, lvl := range lineIndents {
+			if lvl == indent {
+				found = true
+				indentToPath[indent] = currentPath
+				lineIndents = lineIndents[:j+1]
+				break
+			}
+		}
+		if !found {
+			lineIndents = append(lineIndents, indent)
+			indentToPath[indent] = currentPath
+		}
+
+		if len(parts) != 2 {
+			continue
+		}
+
+		if blockScalarIndicatorPattern.MatchString(strings.TrimSpace(parts[1])) {
+			inBlockScalar = true
+			blockScalarIndent = indent
+			blockScalarPath = path
+			continue
+		}
+
+		substituted, tokens, err := interpolateValue(parts[1], env, lineNum)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(tokens) == 0 {
+			continue
+		}
+
+		lines[i] = line[:indent] + parts[0] + ":" + substituted
+		recordTokens(path, tokens)
+	}
+
+	ordered := make([]interpolationResult, 0, len(order))
+	for _, path := range order {
+		ordered = append(ordered, *results[path])
+	}
+
+	return []byte(strings.Join(lines, "\n")), ordered, nil
+}
+
+// interpolateValue substitutes every "${...}" token (and "$$" escape) in
+// value using env, returning the substituted text. If value, once
+// trimmed, is nothing but a single bare (unquoted) token, the substituted
+// text is returned as-is so normal YAML type inference applies to it;
+// otherwise - a token embedded in a larger or quoted string - the result
+// is quoted so the substitution can't accidentally change the field's
+// type or break YAML syntax.
+func interpolateValue(value string, env EnvProvider, lineNum int) (string, []string, error) {
+	trimmed := strings.TrimSpace(value)
+	leading := value[:len(value)-len(strings.TrimLeft(value, " "))]
+
+	quoted := len(trimmed) > 0 && (trimmed[0] == '"' || trimmed[0] == '\'')
+	fullToken := !quoted && envTokenPattern.MatchString(trimmed) && envTokenPattern.FindString(trimmed) == trimmed
+
+	substituted, tokens, err := substituteTokens(trimmed, env, lineNum)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil, nil
+	}
+
+	// A value that was already quoted stays valid YAML as-is once its
+	// tokens are substituted in place; only a bare, non-full-token value
+	// needs quoting added so the substitution can't change its YAML type
+	// or break the line's syntax.
+	if !quoted && !fullToken {
+		substituted = `"` + strings.ReplaceAll(substituted, `"`, `\"`) + `"`
+	}
+
+	return leading + substituted, tokens, nil
+}
+
+// substituteTokens replaces every "${...}" token (and "$$" escape) in text
+// using env, returning the substituted text verbatim - no quoting, no YAML
+// type inference - plus the raw token strings that were replaced. Both
+// interpolateValue (a "key: value" line) and the block-scalar body path in
+// interpolateEnvVars (literal text that must come back out unchanged aside
+// from the substitution) share this core. Text with no "${...}" token is
+// returned unchanged, "$$" escapes included.
+func substituteTokens(text string, env EnvProvider, lineNum int) (string, []string, error) {
+	if unterminated := strings.Count(text, "${") > strings.Count(text, "}"); unterminated {
+		return "", nil, fmt.Errorf("unterminated \"${\" on line %d", lineNum)
+	}
+
+	unescapeMarker := "\x00DOLLAR\x00"
+	working := strings.ReplaceAll(text, "$$", unescapeMarker)
+
+	var tokens []string
+	var lookupErr error
+	substituted := envTokenPattern.ReplaceAllStringFunc(working, func(token string) string {
+		if lookupErr != nil {
+			return token
+		}
+		tokens = append(tokens, token)
+
+		match := envTokenPattern.FindStringSubmatch(token)
+		name, defaultClause, requiredClause := match[1], match[3], match[4]
+
+		resolved, ok := env.Lookup(name)
+		if ok {
+			return resolved
+		}
+		if match[2] != "" && strings.HasPrefix(match[2], ":-") {
+			return defaultClause
+		}
+		if match[2] != "" && strings.HasPrefix(match[2], ":?") {
+			message := requiredClause
+			if message == "" {
+				message = "not set"
+			}
+			lookupErr = fmt.Errorf("required environment variable %q %s (line %d)", name, message, lineNum)
+			return token
+		}
+		return ""
+	})
+	if lookupErr != nil {
+		return "", nil, lookupErr
+	}
+	if len(tokens) == 0 {
+		return text, nil, nil
+	}
+
+	return strings.ReplaceAll(substituted, unescapeMarker, "$"), tokens, nil
+}
+
+// applyInterpolationHints adds an examples entry to every schema node whose
+// path was interpolated (see interpolateEnvVars), showing the raw
+// "${...}" template so anyone reading the generated schema can tell the
+// field is populated from the environment rather than a literal default.
+func applyInterpolationHints(schema *Schema, results []interpolationResult) {
+	if schema == nil || len(results) == 0 {
+		return
+	}
+
+	byPath := make(map[string][]string, len(results))
+	for _, result := range results {
+		byPath[result.path] = result.rawTokens
+	}
+
+	walkSchemas(schema, func(s *Schema) {
+		tokens, ok := byPath[s.HelmPath]
+		if !ok || len(s.Examples) > 0 {
+			return
+		}
+		for _, token := range tokens {
+			s.Examples = append(s.Examples, token)
+		}
+	})
+}
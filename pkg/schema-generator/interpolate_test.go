@@ -0,0 +1,209 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+// mapEnvProvider is an EnvProvider backed by a plain map, for tests that
+// need deterministic environment variable values without touching the
+// process environment.
+type mapEnvProvider map[string]string
+
+func (m mapEnvProvider) Lookup(name string) (string, bool) {
+	v, ok := m[name]
+	return v, ok
+}
+
+func TestGenerateFromYAML_InterpolatesEnvVars(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:       Draft07,
+		InterpolateEnvVars:  true,
+		IncludeExamples:     true,
+		EnvProvider:         mapEnvProvider{"DB_HOST": "db.internal", "DB_INSTANCES": "5432"},
+		ExtractDescriptions: false,
+	})
+
+	yamlData := []byte(`
+database:
+  host: ${DB_HOST}
+  instances: ${DB_INSTANCES}
+  url: "postgres://${DB_HOST}/app"
+  connectAttempts: ${DB_CONNECT_ATTEMPTS:-30}
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	database := schema.Properties["database"]
+	if database == nil {
+		t.Fatal("expected a database property")
+	}
+
+	host := database.Properties["host"]
+	if host.Type != TypeString || len(host.Examples) != 1 || host.Examples[0] != "db.internal" {
+		t.Errorf("expected host to be the string 'db.internal', got type=%v examples=%v", host.Type, host.Examples)
+	}
+
+	instances := database.Properties["instances"]
+	if instances.Type != TypeInteger {
+		t.Errorf("expected a fully-substituted bare numeric token to infer as integer, got %v", instances.Type)
+	}
+
+	url := database.Properties["url"]
+	if len(url.Examples) != 1 || url.Examples[0] != "postgres://db.internal/app" {
+		t.Errorf("expected url to substitute in place, got examples=%v", url.Examples)
+	}
+
+	connectAttempts := database.Properties["connectAttempts"]
+	if connectAttempts.Type != TypeInteger {
+		t.Errorf("expected default-clause substitution to infer as integer, got %v", connectAttempts.Type)
+	}
+}
+
+func TestGenerateFromYAML_InterpolationAddsExampleHint(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:       Draft07,
+		InterpolateEnvVars:  true,
+		EnvProvider:         mapEnvProvider{"REPLICA_COUNT": "3"},
+		ExtractDescriptions: false,
+	})
+
+	schema, err := generator.GenerateFromYAML(ctx, []byte("replicaCount: ${REPLICA_COUNT}\n"))
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	replicaCount := schema.Properties["replicaCount"]
+	if len(replicaCount.Examples) != 1 || replicaCount.Examples[0] != "${REPLICA_COUNT}" {
+		t.Errorf("expected an examples hint with the raw token, got %v", replicaCount.Examples)
+	}
+}
+
+func TestGenerateFromYAML_RequiredEnvVarMissingErrors(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:      Draft07,
+		InterpolateEnvVars: true,
+		EnvProvider:        mapEnvProvider{},
+	})
+
+	_, err := generator.GenerateFromYAML(ctx, []byte("apiKey: ${API_KEY:?must be set}\n"))
+	if err == nil {
+		t.Fatal("expected an error for a missing required environment variable")
+	}
+}
+
+func TestGenerateFromYAML_UnterminatedBraceErrors(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:      Draft07,
+		InterpolateEnvVars: true,
+		EnvProvider:        mapEnvProvider{},
+	})
+
+	_, err := generator.GenerateFromYAML(ctx, []byte("name: ${UNCLOSED\n"))
+	if err == nil {
+		t.Fatal("expected an error for an unterminated '${' token")
+	}
+}
+
+func TestGenerateFromYAML_DollarEscape(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:      Draft07,
+		InterpolateEnvVars: true,
+		EnvProvider:        mapEnvProvider{},
+	})
+
+	_, err := generator.GenerateFromYAML(ctx, []byte(`price: "$$5"`+"\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestInterpolateEnvVars_BlockScalarBodyPassesThroughUnquoted(t *testing.T) {
+	env := mapEnvProvider{"START": "9am", "END": "5pm"}
+
+	yamlData := []byte("notes: |\n  Hours: ${START} to ${END}\n  Contact us anytime.\n")
+
+	substituted, results, err := interpolateEnvVars(yamlData, env)
+	if err != nil {
+		t.Fatalf("interpolateEnvVars failed: %v", err)
+	}
+
+	want := "notes: |\n  Hours: 9am to 5pm\n  Contact us anytime.\n"
+	if string(substituted) != want {
+		t.Errorf("expected the block scalar body to substitute in place without added quotes:\ngot:  %q\nwant: %q", substituted, want)
+	}
+
+	if len(results) != 1 || results[0].path != "notes" {
+		t.Fatalf("expected a single interpolation result for path \"notes\", got %+v", results)
+	}
+	if len(results[0].rawTokens) != 2 {
+		t.Errorf("expected both ${START} and ${END} to be recorded, got %v", results[0].rawTokens)
+	}
+}
+
+func TestGenerateFromYAML_MultiDocumentMerge(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+	yamlData := []byte(`
+replicaCount: 1
+image:
+  repository: nginx
+---
+replicaCount: 3
+image:
+  tag: "1.25"
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	if schema.Properties["image"].Properties["repository"] == nil {
+		t.Error("expected repository from the first document to survive the merge")
+	}
+	if schema.Properties["image"].Properties["tag"] == nil {
+		t.Error("expected tag from the second document to be added by the merge")
+	}
+}
+
+func TestGenerateFromYAML_MultiDocumentConcatSequences(t *testing.T) {
+	yamlData := []byte(`
+ports:
+  - 80
+---
+ports:
+  - 443
+`)
+
+	replaced, err := parseYAMLDocuments(yamlData, false)
+	if err != nil {
+		t.Fatalf("parseYAMLDocuments failed: %v", err)
+	}
+	if ports, ok := replaced["ports"].([]any); !ok || len(ports) != 1 {
+		t.Errorf("expected replace semantics to keep only the later sequence, got %v", replaced["ports"])
+	}
+
+	concatenated, err := parseYAMLDocuments(yamlData, true)
+	if err != nil {
+		t.Fatalf("parseYAMLDocuments failed: %v", err)
+	}
+	if ports, ok := concatenated["ports"].([]any); !ok || len(ports) != 2 {
+		t.Errorf("expected concat semantics to keep both sequences, got %v", concatenated["ports"])
+	}
+}
@@ -10,6 +10,10 @@ import (
 type SchemaVersion string
 
 const (
+	// Draft4 represents JSON Schema draft-04
+	Draft4 SchemaVersion = "http://json-schema.org/draft-04/schema#"
+	// Draft6 represents JSON Schema draft-06
+	Draft6 SchemaVersion = "http://json-schema.org/draft-06/schema#"
 	// Draft07 represents JSON Schema draft-07
 	Draft07 SchemaVersion = "http://json-schema.org/draft-07/schema#"
 	// Draft2019 represents JSON Schema 2019-09
@@ -18,6 +22,28 @@ const (
 	Draft2020 SchemaVersion = "https://json-schema.org/draft/2020-12/schema"
 )
 
+// draftShorthands maps the short draft names accepted by --draft (and any
+// other caller that wants to turn a user-friendly version name into a
+// SchemaVersion) to the SchemaVersion constant they select.
+var draftShorthands = map[string]SchemaVersion{
+	"4":    Draft4,
+	"6":    Draft6,
+	"7":    Draft07,
+	"2019": Draft2019,
+	"2020": Draft2020,
+}
+
+// ParseDraftShorthand resolves a short draft name - "4", "6", "7", "2019",
+// or "2020" - to its SchemaVersion constant, returning an error for any
+// other value.
+func ParseDraftShorthand(shorthand string) (SchemaVersion, error) {
+	version, ok := draftShorthands[shorthand]
+	if !ok {
+		return "", fmt.Errorf("unknown draft %q: must be one of 4, 6, 7, 2019, 2020", shorthand)
+	}
+	return version, nil
+}
+
 // SchemaType represents a JSON Schema type
 type SchemaType string
 
@@ -73,8 +99,67 @@ type Schema struct {
 
 	// Additional schema features
 	Definitions map[string]*Schema `json:"definitions,omitempty"`
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
 	Ref         string             `json:"$ref,omitempty"`
 
+	// AdditionalProperties, when non-nil, emits the legacy
+	// "additionalProperties" keyword, forbidding (false) or allowing (true)
+	// object keys not listed in Properties. Set via a "@additionalProperties
+	// true|false" comment annotation (see CommentExtractor) - nothing in
+	// inference sets it on its own. UnevaluatedProperties is the
+	// composition-aware alternative for 2019-09+.
+	AdditionalProperties *bool `json:"additionalProperties,omitempty"`
+
+	// UnevaluatedProperties, when non-nil, emits "unevaluatedProperties"
+	// (2019-09+ only - see GeneratorOptions.DisallowUnevaluatedProperties),
+	// forbidding (false) or allowing (true) properties left unmatched by
+	// Properties and every applicable oneOf/anyOf/allOf/$ref branch. It is
+	// the composition-aware sibling of the older "additionalProperties".
+	UnevaluatedProperties *bool `json:"unevaluatedProperties,omitempty"`
+
+	// DynamicRef and DynamicAnchor implement the 2020-12 extension point for
+	// recursive, overridable schemas: a "$dynamicRef" resolves to the
+	// nearest enclosing "$dynamicAnchor" of the same name at evaluation
+	// time, rather than lexically like "$ref".
+	DynamicRef    string `json:"$dynamicRef,omitempty"`
+	DynamicAnchor string `json:"$dynamicAnchor,omitempty"`
+
+	// TuplePrefixItems holds positional subschemas for a fixed-shape
+	// heterogeneous array (see GeneratorOptions.InferTuples), rendered on
+	// 2020-12 as "prefixItems" plus "items": false. Mutually exclusive with
+	// Items and TupleLegacyItems.
+	TuplePrefixItems []*Schema `json:"-"`
+
+	// TupleLegacyItems holds positional subschemas for a fixed-shape
+	// heterogeneous array on draft-07/2019-09, rendered as the legacy array
+	// form of "items" plus "additionalItems": false. Mutually exclusive with
+	// Items and TuplePrefixItems.
+	TupleLegacyItems []*Schema `json:"-"`
+
+	// Placeholder marks a schema synthesized for a path that templates
+	// reference via .Values but that is absent from values.yaml (see
+	// GenerateFromChart). It forces Default to render as an explicit JSON
+	// null instead of being omitted, signalling "this key is expected but
+	// has no documented default".
+	Placeholder bool `json:"-"`
+
+	// Deprecated marks a property that MergeSchemas carried over from an
+	// existing schema because it no longer appears in values.yaml, instead
+	// of dropping it outright.
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// XKubernetesValidations holds CEL validation rules emitted by
+	// CELRuleBuilder (see GeneratorOptions.EmitCELValidations) for Helm-idiom
+	// invariants JSON Schema's own keywords can't express.
+	XKubernetesValidations []CELValidationRule `json:"x-kubernetes-validations,omitempty"`
+
+	// XSourceFiles records, for a property inferred by
+	// Generator.InferSchemaFromSources (or GenerateFromMultipleYAML), the
+	// labels of the sources that contributed a non-nil value at this path -
+	// e.g. []string{"source-0", "source-2"}. Nil outside of multi-source
+	// inference.
+	XSourceFiles []string `json:"x-source-files,omitempty"`
+
 	// Additional metadata for Helm values
 	HelmPath string `json:"-"` // Used internally, not rendered in final schema
 }
@@ -83,7 +168,104 @@ type Schema struct {
 func (s Schema) MarshalJSON() ([]byte, error) {
 	// Use a separate type to avoid infinite recursion
 	type SchemaAlias Schema
-	return json.Marshal(SchemaAlias(s))
+
+	needsSplice := len(s.TuplePrefixItems) > 0 || len(s.TupleLegacyItems) > 0 || (s.Placeholder && s.Default == nil)
+	if !needsSplice {
+		return json.Marshal(SchemaAlias(s))
+	}
+
+	// Tuple (fixed-shape heterogeneous array) rendering needs an explicit
+	// `false` for "items"/"additionalItems", and a placeholder's "default"
+	// needs an explicit JSON null - both are zero values that
+	// encoding/json's omitempty would otherwise drop, so splice them in
+	// after the fact.
+	data, err := json.Marshal(SchemaAlias(s))
+	if err != nil {
+		return nil, err
+	}
+
+	var rendered map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rendered); err != nil {
+		return nil, err
+	}
+
+	if len(s.TuplePrefixItems) > 0 {
+		prefixItems, err := json.Marshal(s.TuplePrefixItems)
+		if err != nil {
+			return nil, err
+		}
+		rendered["prefixItems"] = prefixItems
+		rendered["items"] = json.RawMessage("false")
+	} else if len(s.TupleLegacyItems) > 0 {
+		items, err := json.Marshal(s.TupleLegacyItems)
+		if err != nil {
+			return nil, err
+		}
+		rendered["items"] = items
+		rendered["additionalItems"] = json.RawMessage("false")
+	}
+
+	if s.Placeholder && s.Default == nil {
+		rendered["default"] = json.RawMessage("null")
+	}
+
+	return json.Marshal(rendered)
+}
+
+// UnmarshalJSON customizes JSON decoding for the Schema type, reversing
+// MarshalJSON's splice: a spliced tuple ("prefixItems" + "items": false, or
+// the legacy array form of "items" + "additionalItems": false) is restored
+// into TuplePrefixItems/TupleLegacyItems rather than left for the ordinary
+// "items" decode (which would otherwise fail, since those reshape "items"
+// into something a plain *Schema can't represent), and an explicit JSON
+// "default": null is restored into Placeholder.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type SchemaAlias Schema
+	aux := struct {
+		Items json.RawMessage `json:"items,omitempty"`
+		*SchemaAlias
+	}{
+		SchemaAlias: (*SchemaAlias)(s),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var rendered map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rendered); err != nil {
+		return err
+	}
+
+	switch {
+	case len(aux.Items) == 0:
+		// No "items" at all.
+	case string(aux.Items) == "false":
+		if prefixItems, ok := rendered["prefixItems"]; ok {
+			var items []*Schema
+			if err := json.Unmarshal(prefixItems, &items); err != nil {
+				return fmt.Errorf("failed to decode prefixItems: %w", err)
+			}
+			s.TuplePrefixItems = items
+		}
+	case aux.Items[0] == '[':
+		var items []*Schema
+		if err := json.Unmarshal(aux.Items, &items); err != nil {
+			return fmt.Errorf("failed to decode legacy tuple items: %w", err)
+		}
+		s.TupleLegacyItems = items
+	default:
+		var items Schema
+		if err := json.Unmarshal(aux.Items, &items); err != nil {
+			return fmt.Errorf("failed to decode items: %w", err)
+		}
+		s.Items = &items
+	}
+
+	if raw, ok := rendered["default"]; ok && string(raw) == "null" {
+		s.Placeholder = true
+	}
+
+	return nil
 }
 
 // String returns a JSON string representation of the schema
@@ -120,10 +302,189 @@ type GeneratorOptions struct {
 	// UseFullyQualifiedIDs generates fully qualified IDs for all schemas
 	UseFullyQualifiedIDs bool
 
+	// PatternRules holds user-supplied rules for detecting fields that should
+	// support multiple JSON Schema types, in addition to (or, when
+	// ReplacePatternRules is set, instead of) the built-in Helm-idiom table.
+	// See LoadPatternRules for the file format.
+	PatternRules []PatternRule
+
+	// ReplacePatternRules, when true, makes PatternRules the complete set of
+	// rules evaluated rather than merging them ahead of the built-in table.
+	ReplacePatternRules bool
+
+	// MaxEnumValues caps the number of distinct values a leaf can take,
+	// across all samples passed to GenerateFromMultipleYAML, before an enum
+	// constraint is skipped. Defaults to 10 when unset (zero).
+	MaxEnumValues int
+
+	// DedupThreshold is the minimum number of structurally identical object
+	// subschemas required before they're hoisted into a shared $defs /
+	// definitions bucket and replaced with $ref. Defaults to 2 when unset.
+	DedupThreshold int
+
+	// DedupMinProperties skips hoisting object subschemas with fewer than
+	// this many properties, to avoid extracting trivial shapes like
+	// {"enabled": true}. Defaults to 1 when unset.
+	DedupMinProperties int
+
+	// InferTuples enables positional (tuple) schemas for fixed-shape
+	// heterogeneous arrays - e.g. a two-element [name, port] pair - instead
+	// of collapsing every element to a single shared items schema. Disabled
+	// by default to preserve existing behavior.
+	InferTuples bool
+
+	// DisallowUnevaluatedProperties, when true and SchemaVersion is
+	// 2019-09 or 2020-12, sets "unevaluatedProperties: false" on every
+	// generated object schema, closing it to keys not covered by Properties
+	// (or, once composed, by an applicable oneOf/anyOf/allOf/$ref branch).
+	// Has no effect on draft-07 and earlier, which don't have the keyword.
+	DisallowUnevaluatedProperties bool
+
+	// InterpolateEnvVars enables "${name}" / "${name:-default}" /
+	// "${name:?errMessage}" environment-variable interpolation in
+	// GenerateFromYAML, using EnvProvider (or os.LookupEnv if unset) to
+	// resolve names. Disabled by default to preserve existing behavior for
+	// values files that happen to contain literal "${...}" text.
+	InterpolateEnvVars bool
+
+	// EnvProvider supplies environment variable values for
+	// InterpolateEnvVars; it defaults to os.LookupEnv when left nil.
+	EnvProvider EnvProvider
+
+	// ConcatSequencesOnMerge controls how GenerateFromYAML reconciles a
+	// sequence that appears at the same path in more than one
+	// "---"-separated document: concatenating them when true, or letting
+	// the later document's sequence replace the earlier one's (the
+	// default) when false.
+	ConcatSequencesOnMerge bool
+
+	// EmitCELValidations enables CELRuleBuilder, attaching
+	// "x-kubernetes-validations" CEL rules for detected resource blocks,
+	// replicaCount/replicas bounds, image.pullPolicy enums, and
+	// mutually-exclusive sibling properties. Disabled by default, since not
+	// every consumer of the generated schema understands CEL validations.
+	EmitCELValidations bool
+
+	// MaxRuleCost caps the estimated cost (see estimateRuleCost) a CEL rule
+	// may have before CELRuleBuilder drops it instead of emitting it,
+	// mirroring the apiserver's per-rule CEL cost budget. Zero means no cap.
+	MaxRuleCost int
+
+	// ArrayInference controls how a homogeneous array's Items schema is
+	// inferred from its elements. Defaults to ArrayInferenceMergeAll when
+	// left unset.
+	ArrayInference ArrayInferenceMode
+
+	// SpecializeForHelm enables Generator.SpecializeSchemaForHelm, replacing
+	// inferred subschemas that match a registered Recognizer (image,
+	// resources, probes, service, ingress, ...) with a template hand-tuned
+	// for that Helm idiom. Disabled by default to preserve existing
+	// behavior.
+	SpecializeForHelm bool
+
+	// DisabledRecognizers lists Recognizer.Name values to skip when
+	// SpecializeForHelm is enabled, e.g. []string{"image", "resources"}.
+	DisabledRecognizers []string
+
+	// EnabledFormats restricts string-leaf format detection (see
+	// Generator.RegisterFormat and detectFormat) to the named
+	// FormatDetector.Name values, e.g. []string{"ipv4", "uuid"}. Empty means
+	// every built-in and registered detector is tried.
+	EnabledFormats []string
+
+	// ArrayMixedStrategy controls how inferSchema renders a heterogeneous
+	// array's Items schema when hasMixedTypes is true. Defaults to
+	// ArrayMixedUnion when left unset, preserving existing behavior.
+	ArrayMixedStrategy ArrayMixedStrategy
+
+	// MergeStrategy controls how Generator.InferSchemaFromSources (and
+	// GenerateFromMultipleYAML) resolves a property that isn't present, or
+	// doesn't share a single scalar type, across every source. Defaults to
+	// MergeUnion when left unset.
+	MergeStrategy MergeStrategy
+
 	// Debug enables additional debug output during generation
 	Debug bool
 }
 
+// MergeStrategy controls how mergeSamples reconciles a path across the
+// sources passed to Generator.InferSchemaFromSources or
+// GenerateFromMultipleYAML.
+type MergeStrategy string
+
+const (
+	// MergeUnion includes every property observed in any source, marking it
+	// required only when present in all of them, and widens a scalar leaf's
+	// type to the union of every type observed for it. This is the default
+	// and matches the generator's historical behavior.
+	MergeUnion MergeStrategy = "union"
+
+	// MergeIntersection drops a property entirely unless it is present in
+	// every source that has a value at its parent path, rather than keeping
+	// it as an optional property the way MergeUnion does.
+	MergeIntersection MergeStrategy = "intersection"
+
+	// MergeStrict behaves like MergeUnion for property presence, but
+	// InferSchemaFromSources/GenerateFromMultipleYAML return an error
+	// instead of silently widening a scalar leaf's type when its sources
+	// disagree on type - mirroring ArrayInferenceStrict's "error instead of
+	// silently widening" behavior for arrays.
+	MergeStrict MergeStrategy = "strict"
+)
+
+// ArrayInferenceMode controls how Generator infers an array's Items schema
+// from its elements (when they aren't already handled by InferTuples or the
+// mixed-scalar-type handling in InferArrayItemsWithMultipleTypes).
+type ArrayInferenceMode string
+
+const (
+	// ArrayInferenceFirstOnly infers Items from the array's first element
+	// only, ignoring the rest.
+	ArrayInferenceFirstOnly ArrayInferenceMode = "first-only"
+
+	// ArrayInferenceMergeAll infers a schema for every element and folds
+	// them together with mergeSchemas, so Items reflects every element's
+	// properties, types, and format rather than just the first one's. This
+	// is the default.
+	ArrayInferenceMergeAll ArrayInferenceMode = "merge-all"
+
+	// ArrayInferenceStrict behaves like ArrayInferenceMergeAll, but
+	// GenerateFromMap/GenerateFromYAML return an error instead of silently
+	// widening Items if any element's inferred schema doesn't structurally
+	// match the others.
+	ArrayInferenceStrict ArrayInferenceMode = "strict"
+)
+
+// ArrayMixedStrategy controls how inferSchema renders a heterogeneous
+// array's Items schema - one whose elements have different inferred types,
+// per hasMixedTypes - instead of collapsing every observed type into a
+// single Items.Type union.
+type ArrayMixedStrategy string
+
+const (
+	// ArrayMixedUnion renders Items as a single schema whose Type lists
+	// every observed type, e.g. {"type": ["string", "integer"]}. This is
+	// the default and matches the generator's historical behavior.
+	ArrayMixedUnion ArrayMixedStrategy = "union"
+
+	// ArrayMixedOneOf renders Items as {"oneOf": [...]}, with one
+	// subschema per distinct observed item shape (deduplicated by
+	// canonicalHash), requiring each element to match exactly one branch.
+	ArrayMixedOneOf ArrayMixedStrategy = "oneOf"
+
+	// ArrayMixedAnyOf is identical to ArrayMixedOneOf except it renders
+	// Items as {"anyOf": [...]}, requiring each element to match at least
+	// one branch rather than exactly one.
+	ArrayMixedAnyOf ArrayMixedStrategy = "anyOf"
+
+	// ArrayMixedTupleValidation renders the array itself (not Items) as a
+	// fixed-shape tuple with one positional subschema per element, the
+	// same way GeneratorOptions.InferTuples does - "prefixItems" on
+	// 2020-12, the legacy array form of "items" on earlier drafts (see
+	// Generator.assignTupleItems).
+	ArrayMixedTupleValidation ArrayMixedStrategy = "tuple-validation"
+)
+
 // DefaultOptions returns the default generator options
 func DefaultOptions() GeneratorOptions {
 	return GeneratorOptions{
@@ -133,6 +494,9 @@ func DefaultOptions() GeneratorOptions {
 		IncludeExamples:      true,
 		ExtractDescriptions:  true,
 		UseFullyQualifiedIDs: false,
+		MaxEnumValues:        10,
+		DedupThreshold:       2,
+		DedupMinProperties:   1,
 		Debug:                false,
 	}
 }
@@ -141,6 +505,14 @@ func DefaultOptions() GeneratorOptions {
 type Generator struct {
 	Options GeneratorOptions
 	schema  *Schema
+
+	// recognizers holds custom Recognizers registered with
+	// RegisterRecognizer, tried after the built-in registry.
+	recognizers []Recognizer
+
+	// formatDetectors holds custom FormatDetectors registered with
+	// RegisterFormat, tried after the built-in registry.
+	formatDetectors []FormatDetector
 }
 
 // NewGenerator creates a new schema generator with the specified options
@@ -159,3 +531,18 @@ func NewGenerator(options GeneratorOptions) *Generator {
 func NewGeneratorWithDefaults() *Generator {
 	return NewGenerator(DefaultOptions())
 }
+
+// NewGeneratorFromConfig creates a new schema generator with the given options,
+// loading PatternRules from rulesPath if it is non-empty. rulesPath may point to
+// a YAML or JSON file; see LoadPatternRules for the expected format.
+func NewGeneratorFromConfig(options GeneratorOptions, rulesPath string) (*Generator, error) {
+	if rulesPath != "" {
+		rules, err := LoadPatternRulesFile(rulesPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load pattern rules from '%s': %w", rulesPath, err)
+		}
+		options.PatternRules = rules
+	}
+
+	return NewGenerator(options), nil
+}
@@ -0,0 +1,193 @@
+package jsonschema
+
+import "fmt"
+
+// MergeOptions configures MergeSchemas.
+type MergeOptions struct {
+	// DropDeprecated removes properties that were already marked deprecated
+	// in existing and still don't appear in generated, instead of carrying
+	// them forward for another generation.
+	DropDeprecated bool
+}
+
+// MergeConflict records a property MergeSchemas could not cleanly
+// reconcile between an existing, hand-edited schema and a freshly
+// generated one - typically because the inferred type changed in a way
+// incompatible with the existing hand-authored constraints.
+type MergeConflict struct {
+	// Path is the dotted Helm path of the conflicting property.
+	Path string
+	// Message describes the conflict and how it was resolved.
+	Message string
+}
+
+// Issue renders c as a ValidationIssue at Warning level, for display
+// alongside other schema issues via FormatValidationIssues.
+func (c MergeConflict) Issue() ValidationIssue {
+	return ValidationIssue{Path: c.Path, Message: c.Message, Level: Warning}
+}
+
+// MergeConflictIssues converts conflicts to ValidationIssues, for callers
+// that want to report them with FormatValidationIssues.
+func MergeConflictIssues(conflicts []MergeConflict) []ValidationIssue {
+	issues := make([]ValidationIssue, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		issues = append(issues, conflict.Issue())
+	}
+	return issues
+}
+
+// MergeSchemas reconciles an existing, possibly hand-edited schema with one
+// freshly produced by inference: hand-authored Description, Pattern, Enum,
+// Format, Minimum, Maximum, OneOf, AnyOf, AllOf, and Ref are preserved at
+// any path where generated's inferred type is still compatible with
+// existing's; newly discovered properties are added as-is; and properties
+// present in existing but absent from generated are kept and marked
+// Deprecated rather than silently dropped (see MergeOptions.DropDeprecated
+// to stop carrying forward deprecated properties that have since vanished
+// from generated as well). Every path where an incompatible type change
+// forced generated's type to win over existing's is reported as a
+// MergeConflict.
+func MergeSchemas(existing, generated *Schema, opts MergeOptions) (*Schema, []MergeConflict) {
+	var conflicts []MergeConflict
+	merged := mergeNode(existing, generated, "", opts, &conflicts)
+	return merged, conflicts
+}
+
+// mergeNode merges a single schema node, recursing into Properties and
+// Items. Either existing or generated may be nil (a brand-new or a
+// vanished property) but not both.
+func mergeNode(existing, generated *Schema, path string, opts MergeOptions, conflicts *[]MergeConflict) *Schema {
+	if existing == nil {
+		return generated
+	}
+	if generated == nil {
+		if opts.DropDeprecated && existing.Deprecated {
+			return nil
+		}
+		deprecated := copySchema(existing)
+		deprecated.Deprecated = true
+		return deprecated
+	}
+
+	merged := copySchema(generated)
+
+	if typesCompatible(existing.Type, generated.Type) {
+		preserveHandAuthoredFields(existing, merged)
+	} else {
+		*conflicts = append(*conflicts, MergeConflict{
+			Path:    path,
+			Message: fmt.Sprintf("inferred type changed from %v to %v; kept the newly inferred type and dropped existing type-dependent constraints", existing.Type, generated.Type),
+		})
+	}
+
+	merged.Properties = mergeProperties(existing, generated, path, opts, conflicts)
+
+	if existing.Items != nil || generated.Items != nil {
+		merged.Items = mergeNode(existing.Items, generated.Items, path+"[]", opts, conflicts)
+	}
+
+	return merged
+}
+
+// mergeProperties merges existing.Properties and generated.Properties,
+// keeping every property from either side (vanished ones marked
+// deprecated, new ones added as-is).
+func mergeProperties(existing, generated *Schema, path string, opts MergeOptions, conflicts *[]MergeConflict) map[string]*Schema {
+	if existing.Properties == nil && generated.Properties == nil {
+		return nil
+	}
+
+	merged := make(map[string]*Schema, len(generated.Properties))
+	for name, generatedProp := range generated.Properties {
+		merged[name] = mergeNode(existing.Properties[name], generatedProp, childPath(path, name), opts, conflicts)
+	}
+	for name, existingProp := range existing.Properties {
+		if _, handled := generated.Properties[name]; handled {
+			continue
+		}
+		if node := mergeNode(existingProp, nil, childPath(path, name), opts, conflicts); node != nil {
+			merged[name] = node
+		}
+	}
+
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
+
+// preserveHandAuthoredFields copies the hand-authorable fields of existing
+// onto merged, so a user's documentation and constraints survive
+// regeneration wherever the underlying type is still compatible.
+func preserveHandAuthoredFields(existing, merged *Schema) {
+	if existing.Description != "" {
+		merged.Description = existing.Description
+	}
+	if existing.Pattern != "" {
+		merged.Pattern = existing.Pattern
+	}
+	if existing.Format != "" {
+		merged.Format = existing.Format
+	}
+	if len(existing.Enum) > 0 {
+		merged.Enum = existing.Enum
+	}
+	if existing.Minimum != nil {
+		merged.Minimum = existing.Minimum
+	}
+	if existing.Maximum != nil {
+		merged.Maximum = existing.Maximum
+	}
+	if len(existing.OneOf) > 0 {
+		merged.OneOf = existing.OneOf
+	}
+	if len(existing.AnyOf) > 0 {
+		merged.AnyOf = existing.AnyOf
+	}
+	if len(existing.AllOf) > 0 {
+		merged.AllOf = existing.AllOf
+	}
+	if existing.Ref != "" {
+		merged.Ref = existing.Ref
+	}
+}
+
+// copySchema returns a shallow copy of schema, so mergeNode can freely
+// overwrite fields on its result without mutating either input tree.
+func copySchema(schema *Schema) *Schema {
+	copied := *schema
+	return &copied
+}
+
+// typesCompatible reports whether the schema types a and b (each a
+// SchemaType or a []SchemaType, per inferSchema) share at least one common
+// type, meaning a value still valid under a would remain valid under b.
+func typesCompatible(a, b any) bool {
+	aTypes := schemaTypes(a)
+	bTypes := schemaTypes(b)
+	if len(aTypes) == 0 || len(bTypes) == 0 {
+		return true
+	}
+	for _, at := range aTypes {
+		for _, bt := range bTypes {
+			if at == bt {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// schemaTypes normalizes a Schema.Type value (SchemaType or []SchemaType)
+// to a slice for comparison.
+func schemaTypes(t any) []SchemaType {
+	switch v := t.(type) {
+	case SchemaType:
+		return []SchemaType{v}
+	case []SchemaType:
+		return v
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,152 @@
+package jsonschema
+
+import "testing"
+
+func TestMergeSchemas_PreservesHandAuthoredFields(t *testing.T) {
+	existing := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"replicaCount": {
+				Type:        TypeInteger,
+				Description: "Number of pod replicas to run",
+				Minimum:     floatPtr(1),
+			},
+		},
+	}
+
+	generated := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"replicaCount": {Type: TypeInteger},
+		},
+	}
+
+	merged, conflicts := MergeSchemas(existing, generated, MergeOptions{})
+
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %+v", conflicts)
+	}
+
+	replicaCount := merged.Properties["replicaCount"]
+	if replicaCount.Description != "Number of pod replicas to run" {
+		t.Errorf("expected existing description to survive, got %q", replicaCount.Description)
+	}
+	if replicaCount.Minimum == nil || *replicaCount.Minimum != 1 {
+		t.Errorf("expected existing minimum to survive, got %v", replicaCount.Minimum)
+	}
+}
+
+func TestMergeSchemas_AddsNewProperties(t *testing.T) {
+	existing := &Schema{
+		Type:       TypeObject,
+		Properties: map[string]*Schema{"image": {Type: TypeObject}},
+	}
+
+	generated := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"image":        {Type: TypeObject},
+			"replicaCount": {Type: TypeInteger},
+		},
+	}
+
+	merged, _ := MergeSchemas(existing, generated, MergeOptions{})
+
+	if _, ok := merged.Properties["replicaCount"]; !ok {
+		t.Error("expected newly discovered property replicaCount to be added")
+	}
+}
+
+func TestMergeSchemas_MarksVanishedPropertiesDeprecated(t *testing.T) {
+	existing := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"legacyFlag": {Type: TypeBoolean, Description: "old toggle"},
+		},
+	}
+
+	generated := &Schema{
+		Type:       TypeObject,
+		Properties: map[string]*Schema{},
+	}
+
+	merged, _ := MergeSchemas(existing, generated, MergeOptions{})
+
+	legacyFlag, ok := merged.Properties["legacyFlag"]
+	if !ok {
+		t.Fatal("expected vanished property legacyFlag to be kept")
+	}
+	if !legacyFlag.Deprecated {
+		t.Error("expected vanished property to be marked deprecated")
+	}
+	if legacyFlag.Description != "old toggle" {
+		t.Errorf("expected deprecated property to keep its description, got %q", legacyFlag.Description)
+	}
+}
+
+func TestMergeSchemas_DropDeprecatedRemovesAlreadyDeprecatedVanishedProperties(t *testing.T) {
+	existing := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"legacyFlag": {Type: TypeBoolean, Deprecated: true},
+		},
+	}
+
+	generated := &Schema{
+		Type:       TypeObject,
+		Properties: map[string]*Schema{},
+	}
+
+	merged, _ := MergeSchemas(existing, generated, MergeOptions{DropDeprecated: true})
+
+	if _, ok := merged.Properties["legacyFlag"]; ok {
+		t.Error("expected already-deprecated vanished property to be dropped")
+	}
+}
+
+func TestMergeSchemas_ReportsConflictOnIncompatibleTypeChange(t *testing.T) {
+	existing := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"port": {Type: TypeString, Pattern: "^[0-9]+$"},
+		},
+	}
+
+	generated := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"port": {Type: TypeInteger},
+		},
+	}
+
+	merged, conflicts := MergeSchemas(existing, generated, MergeOptions{})
+
+	if len(conflicts) != 1 {
+		t.Fatalf("expected 1 conflict, got %+v", conflicts)
+	}
+	if conflicts[0].Path != "port" {
+		t.Errorf("expected conflict path 'port', got %q", conflicts[0].Path)
+	}
+	if merged.Properties["port"].Type != TypeInteger {
+		t.Errorf("expected newly inferred type to win, got %v", merged.Properties["port"].Type)
+	}
+	if merged.Properties["port"].Pattern != "" {
+		t.Errorf("expected incompatible existing pattern to be dropped, got %q", merged.Properties["port"].Pattern)
+	}
+}
+
+func TestMergeConflictIssues(t *testing.T) {
+	conflicts := []MergeConflict{{Path: "port", Message: "type changed"}}
+
+	issues := MergeConflictIssues(conflicts)
+
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].Level != Warning {
+		t.Errorf("expected Warning level, got %v", issues[0].Level)
+	}
+	if issues[0].Path != "port" {
+		t.Errorf("expected path 'port', got %q", issues[0].Path)
+	}
+}
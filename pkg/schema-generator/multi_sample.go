@@ -0,0 +1,471 @@
+package jsonschema
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/dc-tec/helm-schema-gen/pkg/logging"
+	"gopkg.in/yaml.v2"
+)
+
+// typeOrder defines the canonical order in which observed SchemaTypes are
+// emitted when a leaf takes on more than one type.
+var typeOrder = []SchemaType{
+	TypeString, TypeInteger, TypeNumber, TypeBoolean, TypeObject, TypeArray, TypeNull,
+}
+
+// GenerateFromMultipleYAML generates a single JSON schema by sampling several
+// values files (e.g. values.yaml, values-prod.yaml, values-dev.yaml) and
+// merging their per-path schemas structurally. For each leaf path it unions
+// the observed scalar types with any type-detection pattern rules, tracks
+// whether the path was present in every sample to decide `required`, adds
+// `null` when a sample supplied an explicit nil, and - when a string or
+// number leaf has a value in every sample and takes at most
+// Options.MaxEnumValues distinct values - emits an `enum` constraint.
+func (g *Generator) GenerateFromMultipleYAML(ctx context.Context, sources [][]byte) (*Schema, error) {
+	logger := logging.WithComponent(ctx, "json-schema-generator")
+	logger.InfoContext(ctx, "generating schema from multiple YAML sources", "sources", len(sources))
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no YAML sources provided")
+	}
+
+	values := make([]any, len(sources))
+	present := make([]bool, len(sources))
+
+	for i, source := range sources {
+		var data any
+		if err := yaml.Unmarshal(source, &data); err != nil {
+			logger.ErrorContext(ctx, "failed to unmarshal YAML source", "index", i, "error", err)
+			return nil, fmt.Errorf("failed to unmarshal YAML source %d: %w", i, err)
+		}
+
+		mapped, err := convertYAMLToStringMap(data)
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to convert YAML source", "index", i, "error", err)
+			return nil, fmt.Errorf("failed to convert YAML source %d: %w", i, err)
+		}
+
+		if _, ok := mapped.(map[string]any); !ok {
+			return nil, fmt.Errorf("root YAML value must be a map in source %d, got %T", i, mapped)
+		}
+
+		values[i] = mapped
+		present[i] = true
+	}
+
+	rootSchema, err := g.mergeSamples(ctx, "", values, present, sourceLabels(len(sources)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge samples: %w", err)
+	}
+
+	rootSchema.Schema = g.Options.SchemaVersion
+	rootSchema.Title = g.Options.Title
+	rootSchema.Description = g.Options.Description
+
+	g.deduplicateSchemas(rootSchema)
+
+	if g.Options.ExtractDescriptions {
+		commentExtractor := NewCommentExtractor()
+		commentExtractor.ExtractFromYAML(ctx, sources[0])
+
+		if topComment := commentExtractor.GetComment(""); topComment != "" && rootSchema.Description == "" {
+			rootSchema.Description = topComment
+		}
+
+		commentExtractor.ApplyCommentsToSchema(rootSchema)
+	}
+
+	logger.InfoContext(ctx, "multi-sample schema generation completed")
+	return rootSchema, nil
+}
+
+// maxEnumValues returns the configured maximum number of distinct values
+// allowed before an enum constraint is skipped, defaulting to 10.
+func (g *Generator) maxEnumValues() int {
+	if g.Options.MaxEnumValues > 0 {
+		return g.Options.MaxEnumValues
+	}
+	return 10
+}
+
+// mergeSamples infers a single schema for path from the value observed in
+// each sample. values, present, and labels are parallel slices, one entry
+// per sample; present[i] is false when the path did not exist in sample i,
+// in which case values[i] is meaningless. labels[i] identifies sample i for
+// Schema.XSourceFiles provenance.
+func (g *Generator) mergeSamples(ctx context.Context, path string, values []any, present []bool, labels []string) (*Schema, error) {
+	numPresent := 0
+	for _, p := range present {
+		if p {
+			numPresent++
+		}
+	}
+
+	schema := &Schema{HelmPath: path}
+	if numPresent > 0 {
+		schema.XSourceFiles = presentLabels(present, labels)
+	}
+
+	typeSet := make(map[SchemaType]bool)
+	scalarValues := make(map[string]bool)
+	sawNull := false
+	sawObject := false
+	sawArray := false
+
+	for i, v := range values {
+		if !present[i] {
+			continue
+		}
+		if v == nil {
+			sawNull = true
+			continue
+		}
+
+		switch reflect.TypeOf(v).Kind() {
+		case reflect.Map:
+			sawObject = true
+		case reflect.Slice, reflect.Array:
+			sawArray = true
+		case reflect.Bool:
+			typeSet[TypeBoolean] = true
+			scalarValues[fmt.Sprintf("bool:%v", v)] = true
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			typeSet[TypeInteger] = true
+			scalarValues[fmt.Sprintf("int:%v", v)] = true
+		case reflect.Float32, reflect.Float64:
+			typeSet[TypeNumber] = true
+			scalarValues[fmt.Sprintf("float:%v", v)] = true
+		case reflect.String:
+			typeSet[TypeString] = true
+			scalarValues[fmt.Sprintf("string:%v", v)] = true
+		}
+	}
+
+	// Enum eligibility is decided from what was actually observed, before the
+	// hardcoded pattern rules (subsumed below) are unioned in - a path like
+	// "env" matching the built-in [null, array, string] rule shouldn't stop
+	// it from getting an enum when every sample supplied one of a handful of
+	// distinct strings.
+	observedTypeCount := len(typeSet)
+
+	if g.Options.MergeStrategy == MergeStrict && !sawObject && !sawArray && observedTypeCount > 1 {
+		return nil, fmt.Errorf("merge strategy %q: conflicting types at %q: %v", MergeStrict, path, schemaTypeSlice(typeSet))
+	}
+
+	// Subsume the hardcoded pattern rules: union their types in alongside
+	// whatever was actually observed.
+	if hasMultipleTypes, patternTypes := g.shouldSupportMultipleTypes(path); hasMultipleTypes {
+		for _, t := range patternTypes {
+			typeSet[t] = true
+		}
+	}
+
+	switch {
+	case sawObject:
+		if err := g.mergeObjectSamples(ctx, schema, path, values, present, labels, numPresent); err != nil {
+			return nil, err
+		}
+	case sawArray:
+		if err := g.mergeArraySamples(ctx, schema, path, values, present, labels); err != nil {
+			return nil, err
+		}
+	}
+
+	if sawNull {
+		typeSet[TypeNull] = true
+	}
+
+	if !sawObject && !sawArray {
+		applyScalarType(schema, typeSet)
+
+		// Enum detection: only for a single homogeneous string/number type,
+		// present (non-null) in every sample, within the configured bound.
+		if !sawNull && numPresent == len(present) && observedTypeCount == 1 {
+			for t := range typeSet {
+				if (t == TypeString || t == TypeNumber || t == TypeInteger) && len(scalarValues) <= g.maxEnumValues() {
+					schema.Enum = distinctSchemaValues(values, present)
+				}
+			}
+		}
+	} else if len(typeSet) > 0 {
+		// Structural leaf that also appeared as a scalar/null in some
+		// samples (e.g. matched by a pattern rule, or genuinely mixed data).
+		extra := schemaTypeSlice(typeSet)
+		schema.Type = unionSchemaTypes(schema.Type, extra)
+	}
+
+	return schema, nil
+}
+
+// sourceLabels returns the default "source-0", "source-1", ... labels used
+// when a caller doesn't supply its own, e.g. GenerateFromMultipleYAML, which
+// only has byte slices to work with.
+func sourceLabels(n int) []string {
+	labels := make([]string, n)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("source-%d", i)
+	}
+	return labels
+}
+
+// presentLabels returns the subset of labels whose corresponding present
+// entry is true, for use as a Schema.XSourceFiles value.
+func presentLabels(present []bool, labels []string) []string {
+	var out []string
+	for i, p := range present {
+		if p {
+			out = append(out, labels[i])
+		}
+	}
+	return out
+}
+
+// mergeObjectSamples merges the object-valued samples at path into schema's
+// Properties and Required fields. With Options.MergeStrategy ==
+// MergeIntersection, a property missing from any sample that has an object
+// at path is dropped from Properties entirely instead of being kept as
+// optional.
+func (g *Generator) mergeObjectSamples(ctx context.Context, schema *Schema, path string, values []any, present []bool, labels []string, numPresent int) error {
+	schema.Type = TypeObject
+	schema.Properties = make(map[string]*Schema)
+
+	keys := make(map[string]bool)
+	for i, v := range values {
+		if !present[i] {
+			continue
+		}
+		if m, ok := v.(map[string]any); ok {
+			for k := range m {
+				keys[k] = true
+			}
+		}
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	var required []string
+
+	for _, k := range sortedKeys {
+		childValues := make([]any, len(values))
+		childPresent := make([]bool, len(values))
+		childAvailable := 0
+
+		for i, v := range values {
+			if !present[i] {
+				continue
+			}
+			m, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+			cv, cok := m[k]
+			childPresent[i] = cok
+			childValues[i] = cv
+			if cok {
+				childAvailable++
+			}
+		}
+
+		if g.Options.MergeStrategy == MergeIntersection && numPresent > 0 && childAvailable < numPresent {
+			continue
+		}
+
+		childSchema, err := g.mergeSamples(ctx, childPath(path, k), childValues, childPresent, labels)
+		if err != nil {
+			return fmt.Errorf("failed to merge samples for property '%s': %w", k, err)
+		}
+
+		schema.Properties[k] = childSchema
+
+		if numPresent > 0 && childAvailable == numPresent {
+			required = append(required, k)
+		}
+	}
+
+	if len(required) > 0 {
+		schema.Required = required
+	}
+
+	return nil
+}
+
+// mergeArraySamples merges every element observed across all array-valued
+// samples at path into a single Items schema. When Options.InferTuples is
+// set and every sample's array has the same length L >= 2, it instead tries
+// a positional (tuple) schema, falling back to the homogeneous form when the
+// lengths are inconsistent or the positions turn out to all share one shape.
+func (g *Generator) mergeArraySamples(ctx context.Context, schema *Schema, path string, values []any, present []bool, labels []string) error {
+	schema.Type = TypeArray
+
+	var arrays [][]any
+	var arrayLabels []string
+	for i, v := range values {
+		if !present[i] {
+			continue
+		}
+		if arr, ok := v.([]any); ok {
+			arrays = append(arrays, arr)
+			arrayLabels = append(arrayLabels, labels[i])
+		}
+	}
+
+	if len(arrays) == 0 {
+		return nil
+	}
+
+	if g.Options.InferTuples {
+		built, err := g.tryMergeTupleSamples(ctx, schema, path, arrays, arrayLabels)
+		if err != nil {
+			return err
+		}
+		if built {
+			return nil
+		}
+	}
+
+	var itemValues []any
+	var itemLabels []string
+	for i, arr := range arrays {
+		itemValues = append(itemValues, arr...)
+		for range arr {
+			itemLabels = append(itemLabels, arrayLabels[i])
+		}
+	}
+
+	itemPresent := make([]bool, len(itemValues))
+	for i := range itemPresent {
+		itemPresent[i] = true
+	}
+
+	itemSchema, err := g.mergeSamples(ctx, fmt.Sprintf("%s[0]", path), itemValues, itemPresent, itemLabels)
+	if err != nil {
+		return fmt.Errorf("failed to merge samples for array items: %w", err)
+	}
+
+	schema.Items = itemSchema
+	return nil
+}
+
+// tryMergeTupleSamples attempts to build a positional (tuple) schema from
+// arrays, one slice per sample that had an array present at path. It
+// reports built=false - leaving schema untouched - when the sample lengths
+// are inconsistent (fewer than 2, or not all equal) or the merged positions
+// turn out to all share the same shape, either of which means the plain
+// homogeneous items form should be used instead.
+func (g *Generator) tryMergeTupleSamples(ctx context.Context, schema *Schema, path string, arrays [][]any, labels []string) (bool, error) {
+	length := len(arrays[0])
+	if length < 2 {
+		return false, nil
+	}
+	for _, arr := range arrays[1:] {
+		if len(arr) != length {
+			return false, nil
+		}
+	}
+
+	tupleItems := make([]*Schema, length)
+	for pos := 0; pos < length; pos++ {
+		columnValues := make([]any, len(arrays))
+		columnPresent := make([]bool, len(arrays))
+		for i, arr := range arrays {
+			columnValues[i] = arr[pos]
+			columnPresent[i] = true
+		}
+
+		columnSchema, err := g.mergeSamples(ctx, fmt.Sprintf("%s[%d]", path, pos), columnValues, columnPresent, labels)
+		if err != nil {
+			return false, fmt.Errorf("failed to merge samples for tuple position %d: %w", pos, err)
+		}
+		tupleItems[pos] = columnSchema
+	}
+
+	if tupleItemsAreHomogeneous(tupleItems) {
+		return false, nil
+	}
+
+	g.assignTupleItems(schema, tupleItems)
+	return true, nil
+}
+
+// applyScalarType sets schema.Type from the observed scalar/null type set,
+// in canonical order.
+func applyScalarType(schema *Schema, typeSet map[SchemaType]bool) {
+	types := schemaTypeSlice(typeSet)
+	switch len(types) {
+	case 0:
+		// Unknown/never observed - leave Type unset so any value is allowed.
+	case 1:
+		schema.Type = types[0]
+	default:
+		schema.Type = types
+	}
+}
+
+// schemaTypeSlice renders a type set as a slice in canonical order.
+func schemaTypeSlice(typeSet map[SchemaType]bool) []SchemaType {
+	var types []SchemaType
+	for _, t := range typeOrder {
+		if typeSet[t] {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// unionSchemaTypes merges an existing schema.Type value (string, []SchemaType,
+// or nil) with additional types, returning the result in canonical order.
+func unionSchemaTypes(existing any, extra []SchemaType) any {
+	set := make(map[SchemaType]bool)
+	switch t := existing.(type) {
+	case SchemaType:
+		set[t] = true
+	case []SchemaType:
+		for _, st := range t {
+			set[st] = true
+		}
+	}
+	for _, st := range extra {
+		set[st] = true
+	}
+
+	types := schemaTypeSlice(set)
+	if len(types) == 1 {
+		return types[0]
+	}
+	return types
+}
+
+// childPath joins a parent Helm path and a property key using the same
+// dot-notation convention as inferSchema.
+func childPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return fmt.Sprintf("%s.%s", path, key)
+}
+
+// distinctSchemaValues collects the distinct present values across samples,
+// in first-seen order, for use as an enum constraint.
+func distinctSchemaValues(values []any, present []bool) []any {
+	seen := make(map[string]bool)
+	var enum []any
+	for i, v := range values {
+		if !present[i] {
+			continue
+		}
+		key := fmt.Sprintf("%T:%v", v, v)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		enum = append(enum, v)
+	}
+	return enum
+}
@@ -0,0 +1,188 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGenerateFromMultipleYAML(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RequiredAndOptionalFields", func(t *testing.T) {
+		generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+		sources := [][]byte{
+			[]byte("replicaCount: 1\nenv: prod\n"),
+			[]byte("replicaCount: 2\nenv: dev\n"),
+			[]byte("replicaCount: 3\nenv: staging\nextra: true\n"),
+		}
+
+		schema, err := generator.GenerateFromMultipleYAML(ctx, sources)
+		if err != nil {
+			t.Fatalf("GenerateFromMultipleYAML failed: %v", err)
+		}
+
+		requiredSet := map[string]bool{}
+		for _, r := range schema.Required {
+			requiredSet[r] = true
+		}
+		if !requiredSet["replicaCount"] {
+			t.Error("expected replicaCount to be required (present in every sample)")
+		}
+		if requiredSet["extra"] {
+			t.Error("expected extra to not be required (only present in one sample)")
+		}
+	})
+
+	t.Run("EnumDetection", func(t *testing.T) {
+		generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07, MaxEnumValues: 3})
+
+		sources := [][]byte{
+			[]byte("env: prod\n"),
+			[]byte("env: dev\n"),
+			[]byte("env: staging\n"),
+		}
+
+		schema, err := generator.GenerateFromMultipleYAML(ctx, sources)
+		if err != nil {
+			t.Fatalf("GenerateFromMultipleYAML failed: %v", err)
+		}
+
+		envSchema := schema.Properties["env"]
+		if envSchema == nil {
+			t.Fatal("missing env property")
+		}
+		if len(envSchema.Enum) != 3 {
+			t.Errorf("expected 3 enum values, got %v", envSchema.Enum)
+		}
+	})
+
+	t.Run("EnumSkippedWhenOverLimit", func(t *testing.T) {
+		generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07, MaxEnumValues: 2})
+
+		sources := [][]byte{
+			[]byte("env: prod\n"),
+			[]byte("env: dev\n"),
+			[]byte("env: staging\n"),
+		}
+
+		schema, err := generator.GenerateFromMultipleYAML(ctx, sources)
+		if err != nil {
+			t.Fatalf("GenerateFromMultipleYAML failed: %v", err)
+		}
+
+		if schema.Properties["env"].Enum != nil {
+			t.Errorf("expected no enum when distinct values exceed MaxEnumValues, got %v", schema.Properties["env"].Enum)
+		}
+	})
+
+	t.Run("EnumSkippedWhenSomeSamplesMissing", func(t *testing.T) {
+		generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+		sources := [][]byte{
+			[]byte("env: prod\n"),
+			[]byte("other: 1\n"),
+		}
+
+		schema, err := generator.GenerateFromMultipleYAML(ctx, sources)
+		if err != nil {
+			t.Fatalf("GenerateFromMultipleYAML failed: %v", err)
+		}
+
+		if schema.Properties["env"].Enum != nil {
+			t.Errorf("expected no enum when a sample is missing the field, got %v", schema.Properties["env"].Enum)
+		}
+		for _, r := range schema.Required {
+			if r == "env" {
+				t.Error("expected env to not be required since one sample omits it")
+			}
+		}
+	})
+
+	t.Run("NullAddedToTypeSet", func(t *testing.T) {
+		generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+		sources := [][]byte{
+			[]byte("storageClass: standard\n"),
+			[]byte("storageClass:\n"),
+		}
+
+		schema, err := generator.GenerateFromMultipleYAML(ctx, sources)
+		if err != nil {
+			t.Fatalf("GenerateFromMultipleYAML failed: %v", err)
+		}
+
+		types, ok := schema.Properties["storageClass"].Type.([]SchemaType)
+		if !ok {
+			t.Fatalf("expected storageClass to have multiple types, got %v", schema.Properties["storageClass"].Type)
+		}
+		hasNull := false
+		for _, ty := range types {
+			if ty == TypeNull {
+				hasNull = true
+			}
+		}
+		if !hasNull {
+			t.Errorf("expected null in type set, got %v", types)
+		}
+	})
+
+	t.Run("NestedObjectMerge", func(t *testing.T) {
+		generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+		sources := [][]byte{
+			[]byte("image:\n  repository: nginx\n  tag: \"1.0\"\n"),
+			[]byte("image:\n  repository: nginx\n  pullPolicy: Always\n"),
+		}
+
+		schema, err := generator.GenerateFromMultipleYAML(ctx, sources)
+		if err != nil {
+			t.Fatalf("GenerateFromMultipleYAML failed: %v", err)
+		}
+
+		imageSchema := schema.Properties["image"]
+		if imageSchema == nil || imageSchema.Type != TypeObject {
+			t.Fatalf("expected image to be an object, got %+v", imageSchema)
+		}
+
+		requiredSet := map[string]bool{}
+		for _, r := range imageSchema.Required {
+			requiredSet[r] = true
+		}
+		if !requiredSet["repository"] {
+			t.Error("expected repository to be required within image")
+		}
+		if requiredSet["tag"] || requiredSet["pullPolicy"] {
+			t.Error("expected tag and pullPolicy to not be required within image")
+		}
+	})
+
+	t.Run("ArrayItemsMergedAcrossSamples", func(t *testing.T) {
+		generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+		sources := [][]byte{
+			[]byte("hosts:\n  - chart-example.local\n"),
+			[]byte("hosts:\n  - other.example.com\n  - another.example.com\n"),
+		}
+
+		schema, err := generator.GenerateFromMultipleYAML(ctx, sources)
+		if err != nil {
+			t.Fatalf("GenerateFromMultipleYAML failed: %v", err)
+		}
+
+		hostsSchema := schema.Properties["hosts"]
+		if hostsSchema == nil || hostsSchema.Type != TypeArray {
+			t.Fatalf("expected hosts to be an array, got %+v", hostsSchema)
+		}
+		if hostsSchema.Items == nil || hostsSchema.Items.Type != TypeString {
+			t.Fatalf("expected hosts items to be string, got %+v", hostsSchema.Items)
+		}
+	})
+
+	t.Run("NoSources", func(t *testing.T) {
+		generator := NewGeneratorWithDefaults()
+		if _, err := generator.GenerateFromMultipleYAML(ctx, nil); err == nil {
+			t.Error("expected an error when no sources are provided")
+		}
+	})
+}
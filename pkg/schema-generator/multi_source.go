@@ -0,0 +1,47 @@
+package jsonschema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dc-tec/helm-schema-gen/pkg/logging"
+)
+
+// InferSchemaFromSources infers a single schema from N already-parsed values
+// documents - e.g. values.yaml, values-prod.yaml, values-staging.yaml -
+// merging them the same way GenerateFromMultipleYAML merges parsed YAML
+// sources, but without the YAML-unmarshal step. A field that is an int in
+// one source and a string in another becomes a union type (or, under
+// Options.MergeStrategy == MergeStrict, an error); a field present in only
+// some sources becomes non-required. Each source is labeled "source-0",
+// "source-1", ... in order, and that label is recorded on every property it
+// contributed to via Schema.XSourceFiles.
+func (g *Generator) InferSchemaFromSources(ctx context.Context, sources ...map[string]any) (*Schema, error) {
+	logger := logging.WithComponent(ctx, "json-schema-generator")
+	logger.InfoContext(ctx, "inferring schema from multiple sources", "sources", len(sources))
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no sources provided")
+	}
+
+	values := make([]any, len(sources))
+	present := make([]bool, len(sources))
+	for i, source := range sources {
+		values[i] = any(source)
+		present[i] = true
+	}
+
+	rootSchema, err := g.mergeSamples(ctx, "", values, present, sourceLabels(len(sources)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge sources: %w", err)
+	}
+
+	rootSchema.Schema = g.Options.SchemaVersion
+	rootSchema.Title = g.Options.Title
+	rootSchema.Description = g.Options.Description
+
+	g.deduplicateSchemas(rootSchema)
+
+	logger.InfoContext(ctx, "multi-source schema inference completed")
+	return rootSchema, nil
+}
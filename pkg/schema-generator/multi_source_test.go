@@ -0,0 +1,112 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInferSchemaFromSources(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RequiredAndOptionalFields", func(t *testing.T) {
+		generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+		schema, err := generator.InferSchemaFromSources(ctx,
+			map[string]any{"replicaCount": 1, "env": "prod"},
+			map[string]any{"replicaCount": 2, "env": "dev"},
+			map[string]any{"replicaCount": 3, "env": "staging", "extra": true},
+		)
+		if err != nil {
+			t.Fatalf("InferSchemaFromSources failed: %v", err)
+		}
+
+		requiredSet := map[string]bool{}
+		for _, r := range schema.Required {
+			requiredSet[r] = true
+		}
+		if !requiredSet["replicaCount"] {
+			t.Error("expected replicaCount to be required (present in every source)")
+		}
+		if requiredSet["extra"] {
+			t.Error("expected extra to not be required (only present in one source)")
+		}
+	})
+
+	t.Run("TypeUnionAcrossSources", func(t *testing.T) {
+		generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+		schema, err := generator.InferSchemaFromSources(ctx,
+			map[string]any{"retries": 3},
+			map[string]any{"retries": "3"},
+		)
+		if err != nil {
+			t.Fatalf("InferSchemaFromSources failed: %v", err)
+		}
+
+		types, ok := schema.Properties["retries"].Type.([]SchemaType)
+		if !ok || len(types) != 2 {
+			t.Errorf("expected retries to be a [string, integer] union, got %#v", schema.Properties["retries"].Type)
+		}
+	})
+
+	t.Run("SourceFileProvenance", func(t *testing.T) {
+		generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+		schema, err := generator.InferSchemaFromSources(ctx,
+			map[string]any{"replicaCount": 1},
+			map[string]any{"replicaCount": 2, "extra": true},
+		)
+		if err != nil {
+			t.Fatalf("InferSchemaFromSources failed: %v", err)
+		}
+
+		replicaSources := schema.Properties["replicaCount"].XSourceFiles
+		if len(replicaSources) != 2 || replicaSources[0] != "source-0" || replicaSources[1] != "source-1" {
+			t.Errorf("expected replicaCount to be attributed to both sources, got %v", replicaSources)
+		}
+
+		extraSources := schema.Properties["extra"].XSourceFiles
+		if len(extraSources) != 1 || extraSources[0] != "source-1" {
+			t.Errorf("expected extra to be attributed to source-1 only, got %v", extraSources)
+		}
+	})
+
+	t.Run("MergeIntersectionDropsPartialProperties", func(t *testing.T) {
+		generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07, MergeStrategy: MergeIntersection})
+
+		schema, err := generator.InferSchemaFromSources(ctx,
+			map[string]any{"replicaCount": 1, "env": "prod"},
+			map[string]any{"replicaCount": 2},
+		)
+		if err != nil {
+			t.Fatalf("InferSchemaFromSources failed: %v", err)
+		}
+
+		if _, ok := schema.Properties["env"]; ok {
+			t.Error("expected env to be dropped entirely under MergeIntersection")
+		}
+		if _, ok := schema.Properties["replicaCount"]; !ok {
+			t.Error("expected replicaCount (present in every source) to survive MergeIntersection")
+		}
+	})
+
+	t.Run("MergeStrictErrorsOnTypeConflict", func(t *testing.T) {
+		generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07, MergeStrategy: MergeStrict})
+
+		_, err := generator.InferSchemaFromSources(ctx,
+			map[string]any{"retries": 3},
+			map[string]any{"retries": "3"},
+		)
+		if err == nil {
+			t.Error("expected MergeStrict to error on a type conflict instead of widening to a union")
+		}
+	})
+
+	t.Run("NoSources", func(t *testing.T) {
+		generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+		if _, err := generator.InferSchemaFromSources(ctx); err == nil {
+			t.Error("expected an error when no sources are provided")
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package jsonschema
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v2"
+)
+
+// parseYAMLDocuments decodes every "---"-separated document in yamlData,
+// converting each to a map[string]any, and merges them into one map -
+// recursively merging nested maps, concatenating or replacing sequences
+// per concatSequences (see GeneratorOptions.ConcatSequencesOnMerge), and
+// otherwise letting a later document's scalar win over an earlier one's.
+// A single-document file behaves exactly like a plain yaml.Unmarshal.
+func parseYAMLDocuments(yamlData []byte, concatSequences bool) (map[string]any, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(yamlData))
+
+	merged := map[string]any{}
+	documentCount := 0
+
+	for {
+		var doc any
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal YAML document %d: %w", documentCount, err)
+		}
+		if doc == nil {
+			// An empty document (e.g. a trailing "---" with nothing after
+			// it) decodes to nil; it contributes nothing to the merge.
+			continue
+		}
+		documentCount++
+
+		converted, err := convertYAMLToStringMap(doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert YAML document %d: %w", documentCount, err)
+		}
+
+		docMap, ok := converted.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("root YAML value must be a map in document %d, got %T", documentCount, converted)
+		}
+
+		merged = mergeYAMLMaps(merged, docMap, concatSequences)
+	}
+
+	if documentCount == 0 {
+		return nil, fmt.Errorf("root YAML value must be a map, got <nil>")
+	}
+
+	return merged, nil
+}
+
+// mergeYAMLMaps merges src into dst: nested maps merge recursively, []any
+// values concatenate when concatSequences is set and replace otherwise, and
+// every other value in src (including a replaced sequence) simply
+// overwrites dst's - later documents win.
+func mergeYAMLMaps(dst, src map[string]any, concatSequences bool) map[string]any {
+	for key, srcValue := range src {
+		dstValue, exists := dst[key]
+		if !exists {
+			dst[key] = srcValue
+			continue
+		}
+
+		dstMap, dstIsMap := dstValue.(map[string]any)
+		srcMap, srcIsMap := srcValue.(map[string]any)
+		if dstIsMap && srcIsMap {
+			dst[key] = mergeYAMLMaps(dstMap, srcMap, concatSequences)
+			continue
+		}
+
+		dstSlice, dstIsSlice := dstValue.([]any)
+		srcSlice, srcIsSlice := srcValue.([]any)
+		if concatSequences && dstIsSlice && srcIsSlice {
+			dst[key] = append(append([]any{}, dstSlice...), srcSlice...)
+			continue
+		}
+
+		dst[key] = srcValue
+	}
+	return dst
+}
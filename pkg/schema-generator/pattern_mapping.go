@@ -1,7 +1,14 @@
 // Package patternmapping provides functionality for generating JSON Schema from Go values.
 package jsonschema
 
-import "strings"
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
 
 // patternMapping defines a mapping between path patterns and corresponding schema types
 type patternMapping struct {
@@ -13,160 +20,272 @@ type patternMapping struct {
 	types []SchemaType
 }
 
-// shouldSupportMultipleTypes checks if a field at the given path should support multiple types
-// based on common patterns in Helm chart values.yaml files
-func shouldSupportMultipleTypes(path string) (bool, []SchemaType) {
-	pathLower := strings.ToLower(path)
-
-	// Define pattern mappings - each entry defines patterns and corresponding types
-	patternMappings := []patternMapping{
-		{
-			// Fields that should support object or string types (typically YAML or JSON strings)
-			patterns: []string{
-				"annotations", "labels", "nodeselector", "securitycontext",
-				"affinity", "strategy", "networkpolicy", "objectselector",
-				"poddisruptionbudget", "hostaliases", "matchlabels",
-				"nodeaffinity", "podaffinity", "podantiaffinity", "selector",
-				"topology", "rules", "expressions", "rollingupdate",
-			},
-			matchType: "contains",
-			types:     []SchemaType{TypeObject, TypeString},
-		},
-		{
-			// Fields that should support string or boolean types
-			patterns: []string{
-				"autoscaling", "forceupgrade", "createnamespace", "autosync",
-				"persistence", "tls", "auth", "hostnetwork", "hostpid", "hostipc",
-				"singlenamespace", "debug", "rbac", "monitoring", "istio",
-				"serviceaccount", "automounttoken", "priorityclass", "metrics",
-				"tracing",
-			},
-			matchType: "contains",
-			types:     []SchemaType{TypeBoolean, TypeString},
+// PatternRule describes a single path-matching rule used to infer that a field
+// should support multiple JSON Schema types (e.g. `enabled` fields that accept
+// both a boolean and a templated string). Rules can be loaded from a user
+// supplied YAML or JSON file via LoadPatternRules and attached to
+// GeneratorOptions.PatternRules to extend or replace the built-in table.
+type PatternRule struct {
+	// Path is the substring, suffix, exact value, or regular expression to
+	// match against the Helm value's dot-notation path, depending on Match.
+	Path string `yaml:"path" json:"path"`
+	// Match determines how Path is matched against the value path. One of
+	// "contains", "suffix", "exact", "exact-or-suffix", or "regex".
+	Match string `yaml:"match" json:"match"`
+	// CaseInsensitive lowercases both Path and the value path before matching.
+	CaseInsensitive bool `yaml:"caseInsensitive" json:"caseInsensitive"`
+	// Types are the schema types returned when the rule matches.
+	Types []SchemaType `yaml:"types" json:"types"`
+}
+
+// defaultPatternMappings holds the built-in Helm-idiom rules, grouped by the
+// set of types they produce. defaultPatternRules() flattens this table into
+// individual PatternRule entries.
+var defaultPatternMappings = []patternMapping{
+	{
+		// Fields that should support object or string types (typically YAML or JSON strings)
+		patterns: []string{
+			"annotations", "labels", "nodeselector", "securitycontext",
+			"affinity", "strategy", "networkpolicy", "objectselector",
+			"poddisruptionbudget", "hostaliases", "matchlabels",
+			"nodeaffinity", "podaffinity", "podantiaffinity", "selector",
+			"topology", "rules", "expressions", "rollingupdate",
 		},
-		{
-			// Special case for enabled fields
-			patterns:  []string{"enabled"},
-			matchType: "exact-or-suffix",
-			types:     []SchemaType{TypeBoolean, TypeString},
+		matchType: "contains",
+		types:     []SchemaType{TypeObject, TypeString},
+	},
+	{
+		// Fields that should support string or boolean types
+		patterns: []string{
+			"autoscaling", "forceupgrade", "createnamespace", "autosync",
+			"persistence", "tls", "auth", "hostnetwork", "hostpid", "hostipc",
+			"singlenamespace", "debug", "rbac", "monitoring", "istio",
+			"serviceaccount", "automounttoken", "priorityclass", "metrics",
+			"tracing",
 		},
-		{
-			// Fields that should support null, array, or string types
-			patterns: []string{
-				"tolerations", "topologyspreadconstraints", "volumes",
-				"initcontainers", "extracontainers", "volumemounts",
-				"imagepullsecrets", "hostalias", "sidecars", "extravolumes",
-				"extrainitcontainers", "envfrom", "args", "command", "ports",
-				"env", "environment", "secrets", "configmaps", "pods",
-				"endpoints", "tls.hosts", "ingress.hosts", "hostAliases",
-				"deploymentannotations", "podsecuritycontext", "permissions",
-			},
-			matchType: "contains",
-			types:     []SchemaType{TypeNull, TypeArray, TypeString},
+		matchType: "contains",
+		types:     []SchemaType{TypeBoolean, TypeString},
+	},
+	{
+		// Special case for enabled fields
+		patterns:  []string{"enabled"},
+		matchType: "exact-or-suffix",
+		types:     []SchemaType{TypeBoolean, TypeString},
+	},
+	{
+		// Fields that should support null, array, or string types
+		patterns: []string{
+			"tolerations", "topologyspreadconstraints", "volumes",
+			"initcontainers", "extracontainers", "volumemounts",
+			"imagepullsecrets", "hostalias", "sidecars", "extravolumes",
+			"extrainitcontainers", "envfrom", "args", "command", "ports",
+			"env", "environment", "secrets", "configmaps", "pods",
+			"endpoints", "tls.hosts", "ingress.hosts", "hostAliases",
+			"deploymentannotations", "podsecuritycontext", "permissions",
 		},
-		{
-			// Fields that should support null and string
-			patterns: []string{
-				"secretname", "storageclass", "servicenodeport", "priorityclassname",
-				"certname", "keyname", "cabundle", "ingressclassname", "authsecret",
-				"namespace", "finalizer", "servicename", "clusterrole", "role",
-				"healthcheckpath", "mountpath", "filename", "secretkey", "timezone",
-				"bootstrapservers", "topic",
-			},
-			matchType: "contains",
-			types:     []SchemaType{TypeNull, TypeString},
+		matchType: "contains",
+		types:     []SchemaType{TypeNull, TypeArray, TypeString},
+	},
+	{
+		// Fields that should support null and string
+		patterns: []string{
+			"secretname", "storageclass", "servicenodeport", "priorityclassname",
+			"certname", "keyname", "cabundle", "ingressclassname", "authsecret",
+			"namespace", "finalizer", "servicename", "clusterrole", "role",
+			"healthcheckpath", "mountpath", "filename", "secretkey", "timezone",
+			"bootstrapservers", "topic",
 		},
-		{
-			// Fields that should support null and integer
-			patterns: []string{
-				"maxunavailable", "nodeport", "replicacount", "replicas",
-				"port", "targetport", "containerport", "serviceport", "metricsport",
-				"healthport", "readinessport", "maxreplicas", "minreplicas",
-				"terminationgraceperiodseconds", "backofflimit", "failurethreshold",
-				"successthreshold", "initialdelayseconds", "timeoutseconds",
-				"periodseconds", "minavailable", "retention", "timeout", "limit",
-				"weight",
-			},
-			matchType: "contains",
-			types:     []SchemaType{TypeNull, TypeInteger},
+		matchType: "contains",
+		types:     []SchemaType{TypeNull, TypeString},
+	},
+	{
+		// Fields that should support null and integer
+		patterns: []string{
+			"maxunavailable", "nodeport", "replicacount", "replicas",
+			"port", "targetport", "containerport", "serviceport", "metricsport",
+			"healthport", "readinessport", "maxreplicas", "minreplicas",
+			"terminationgraceperiodseconds", "backofflimit", "failurethreshold",
+			"successthreshold", "initialdelayseconds", "timeoutseconds",
+			"periodseconds", "minavailable", "retention", "timeout", "limit",
+			"weight",
 		},
-		{
-			// Fields that should support string and object (config blocks)
-			patterns: []string{
-				"config", "extraenv", "extraenvironmentvars", "extravolumeconfig",
-				"configuration", "settings", "options", "parameters", "properties",
-				"authentication", "authorization", "security", "networking",
-				"customvalues", "extraconfigs",
-			},
-			matchType: "contains",
-			types:     []SchemaType{TypeString, TypeObject},
+		matchType: "contains",
+		types:     []SchemaType{TypeNull, TypeInteger},
+	},
+	{
+		// Fields that should support string and object (config blocks)
+		patterns: []string{
+			"config", "extraenv", "extraenvironmentvars", "extravolumeconfig",
+			"configuration", "settings", "options", "parameters", "properties",
+			"authentication", "authorization", "security", "networking",
+			"customvalues", "extraconfigs",
 		},
-		{
-			// Fields that should support multiple numeric types
-			patterns: []string{
-				"resources.limits.memory", "resources.requests.memory", "memory",
-				"resources.limits.cpu", "resources.requests.cpu", "cpu",
-				"resources.limits", "resources.requests",
-				"threshold", "percentage", "ratio", "factor", "scalar", "weight",
-				"scale", "bytes", "size", "quota", "maxsurge", "minavailable",
-				"retention",
-			},
-			matchType: "contains",
-			types:     []SchemaType{TypeString, TypeInteger, TypeNumber},
+		matchType: "contains",
+		types:     []SchemaType{TypeString, TypeObject},
+	},
+	{
+		// Fields that should support multiple numeric types
+		patterns: []string{
+			"resources.limits.memory", "resources.requests.memory", "memory",
+			"resources.limits.cpu", "resources.requests.cpu", "cpu",
+			"resources.limits", "resources.requests",
+			"threshold", "percentage", "ratio", "factor", "scalar", "weight",
+			"scale", "bytes", "size", "quota", "maxsurge", "minavailable",
+			"retention",
 		},
-		{
-			// Fields that should support string, integer, and boolean
-			patterns: []string{
-				"preference", "mode", "state", "status", "level", "type",
-				"policy", "protocol",
-			},
-			matchType: "contains",
-			types:     []SchemaType{TypeString, TypeInteger, TypeBoolean},
+		matchType: "contains",
+		types:     []SchemaType{TypeString, TypeInteger, TypeNumber},
+	},
+	{
+		// Fields that should support string, integer, and boolean
+		patterns: []string{
+			"preference", "mode", "state", "status", "level", "type",
+			"policy", "protocol",
 		},
-		{
-			// Fields that likely contain JSON
-			patterns: []string{
-				"json", "raw", "patch", "template", "customdata", "extradata",
-				"override", "manifest",
-			},
-			matchType: "contains",
-			types:     []SchemaType{TypeString, TypeObject, TypeArray},
+		matchType: "contains",
+		types:     []SchemaType{TypeString, TypeInteger, TypeBoolean},
+	},
+	{
+		// Fields that likely contain JSON
+		patterns: []string{
+			"json", "raw", "patch", "template", "customdata", "extradata",
+			"override", "manifest",
 		},
-		{
-			// Kubernetes API specific fields
-			patterns: []string{
-				"containerport", "servicetype", "ingresstype", "secrettype",
-				"podannotations", "accessmodes", "pathtype", "readinessprobe",
-				"livenessprobe", "startupprobe", "volumesource", "volumetype",
-				"service.containerport",
-			},
-			matchType: "contains",
-			types:     []SchemaType{TypeString, TypeObject, TypeArray},
+		matchType: "contains",
+		types:     []SchemaType{TypeString, TypeObject, TypeArray},
+	},
+	{
+		// Kubernetes API specific fields
+		patterns: []string{
+			"containerport", "servicetype", "ingresstype", "secrettype",
+			"podannotations", "accessmodes", "pathtype", "readinessprobe",
+			"livenessprobe", "startupprobe", "volumesource", "volumetype",
+			"service.containerport",
 		},
-	}
+		matchType: "contains",
+		types:     []SchemaType{TypeString, TypeObject, TypeArray},
+	},
+}
 
-	// Check each pattern mapping to see if path matches
-	for _, mapping := range patternMappings {
+// defaultPatternRules flattens defaultPatternMappings into individual
+// PatternRule entries, in the same evaluation order as the original table.
+func defaultPatternRules() []PatternRule {
+	var rules []PatternRule
+	for _, mapping := range defaultPatternMappings {
 		for _, pattern := range mapping.patterns {
-			isMatch := false
-
-			switch mapping.matchType {
-			case "contains":
-				isMatch = strings.Contains(pathLower, pattern)
-			case "suffix":
-				isMatch = strings.HasSuffix(pathLower, pattern)
-			case "exact":
-				isMatch = pathLower == pattern
-			case "exact-or-suffix":
-				isMatch = pathLower == pattern || strings.HasSuffix(pathLower, "."+pattern)
-			}
-
-			if isMatch {
-				return true, mapping.types
-			}
+			rules = append(rules, PatternRule{
+				Path:            pattern,
+				Match:           mapping.matchType,
+				CaseInsensitive: true,
+				Types:           mapping.types,
+			})
+		}
+	}
+	return rules
+}
+
+// shouldSupportMultipleTypes checks if a field at the given path should support multiple types
+// based on the built-in Helm-idiom pattern table. Generators that need to honor
+// user-supplied PatternRules should call (*Generator).shouldSupportMultipleTypes instead.
+func shouldSupportMultipleTypes(path string) (bool, []SchemaType) {
+	return matchPatternRules(path, defaultPatternRules())
+}
+
+// shouldSupportMultipleTypes checks if a field at the given path should support multiple
+// types, honoring any PatternRules configured on the generator. When no custom rules are
+// set, this behaves exactly like the package-level shouldSupportMultipleTypes.
+func (g *Generator) shouldSupportMultipleTypes(path string) (bool, []SchemaType) {
+	return matchPatternRules(path, g.effectivePatternRules())
+}
+
+// effectivePatternRules returns the pattern rules a generator should evaluate,
+// combining any user-supplied rules with the built-in table unless
+// ReplacePatternRules is set.
+func (g *Generator) effectivePatternRules() []PatternRule {
+	if len(g.Options.PatternRules) == 0 {
+		return defaultPatternRules()
+	}
+
+	if g.Options.ReplacePatternRules {
+		return g.Options.PatternRules
+	}
+
+	// Custom rules are evaluated first so chart authors can override or
+	// narrow the built-in conventions.
+	rules := make([]PatternRule, 0, len(g.Options.PatternRules)+len(defaultPatternMappings))
+	rules = append(rules, g.Options.PatternRules...)
+	rules = append(rules, defaultPatternRules()...)
+	return rules
+}
+
+// matchPatternRules evaluates path against rules in order, returning the types
+// from the first rule that matches.
+func matchPatternRules(path string, rules []PatternRule) (bool, []SchemaType) {
+	for _, rule := range rules {
+		candidate := path
+		pattern := rule.Path
+
+		if rule.CaseInsensitive {
+			candidate = strings.ToLower(candidate)
+			pattern = strings.ToLower(pattern)
+		}
+
+		if matchesPattern(candidate, pattern, rule.Match) {
+			return true, rule.Types
 		}
 	}
 
-	// If not a special case, it doesn't need multiple types
 	return false, nil
 }
+
+// matchesPattern applies a single match mode to a candidate path.
+func matchesPattern(candidate, pattern, matchType string) bool {
+	switch matchType {
+	case "contains":
+		return strings.Contains(candidate, pattern)
+	case "suffix":
+		return strings.HasSuffix(candidate, pattern)
+	case "exact":
+		return candidate == pattern
+	case "exact-or-suffix":
+		return candidate == pattern || strings.HasSuffix(candidate, "."+pattern)
+	case "regex":
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false
+		}
+		return re.MatchString(candidate)
+	default:
+		return false
+	}
+}
+
+// LoadPatternRules parses a YAML or JSON document containing a list of
+// PatternRule entries, as produced by hand or exported from another chart.
+func LoadPatternRules(data []byte) ([]PatternRule, error) {
+	var rules []PatternRule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse pattern rules: %w", err)
+	}
+
+	for i, rule := range rules {
+		switch rule.Match {
+		case "contains", "suffix", "exact", "exact-or-suffix", "regex":
+			// valid
+		default:
+			return nil, fmt.Errorf("pattern rule %d (%q): unknown match type %q", i, rule.Path, rule.Match)
+		}
+	}
+
+	return rules, nil
+}
+
+// LoadPatternRulesFile reads and parses a PatternRule file from disk.
+func LoadPatternRulesFile(path string) ([]PatternRule, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- caller-supplied config path
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pattern rules file: %w", err)
+	}
+
+	return LoadPatternRules(data)
+}
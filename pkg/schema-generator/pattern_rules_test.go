@@ -0,0 +1,145 @@
+package jsonschema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadPatternRules(t *testing.T) {
+	t.Run("ValidYAML", func(t *testing.T) {
+		data := []byte(`
+- path: "image.tag"
+  match: contains
+  caseInsensitive: true
+  types: [string, integer]
+- path: "^foo\\."
+  match: regex
+  types: [string]
+`)
+		rules, err := LoadPatternRules(data)
+		if err != nil {
+			t.Fatalf("LoadPatternRules failed: %v", err)
+		}
+		if len(rules) != 2 {
+			t.Fatalf("expected 2 rules, got %d", len(rules))
+		}
+		if rules[0].Path != "image.tag" || rules[0].Match != "contains" {
+			t.Errorf("unexpected first rule: %+v", rules[0])
+		}
+		if len(rules[0].Types) != 2 || rules[0].Types[0] != TypeString || rules[0].Types[1] != TypeInteger {
+			t.Errorf("unexpected types for first rule: %v", rules[0].Types)
+		}
+	})
+
+	t.Run("UnknownMatchType", func(t *testing.T) {
+		data := []byte(`
+- path: "image.tag"
+  match: fuzzy
+  types: [string]
+`)
+		if _, err := LoadPatternRules(data); err == nil {
+			t.Fatal("expected error for unknown match type")
+		}
+	})
+
+	t.Run("FromFile", func(t *testing.T) {
+		dir := t.TempDir()
+		rulesPath := filepath.Join(dir, "rules.yaml")
+		content := "- path: \"custom\"\n  match: contains\n  types: [string]\n"
+		if err := os.WriteFile(rulesPath, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write rules file: %v", err)
+		}
+
+		rules, err := LoadPatternRulesFile(rulesPath)
+		if err != nil {
+			t.Fatalf("LoadPatternRulesFile failed: %v", err)
+		}
+		if len(rules) != 1 || rules[0].Path != "custom" {
+			t.Errorf("unexpected rules loaded from file: %+v", rules)
+		}
+	})
+}
+
+func TestGeneratorPatternRules(t *testing.T) {
+	t.Run("MergesWithBuiltins", func(t *testing.T) {
+		g := NewGenerator(GeneratorOptions{
+			PatternRules: []PatternRule{
+				{Path: "customfield", Match: "contains", CaseInsensitive: true, Types: []SchemaType{TypeString, TypeInteger}},
+			},
+		})
+
+		hasMultipleTypes, types := g.shouldSupportMultipleTypes("app.customField")
+		if !hasMultipleTypes {
+			t.Fatal("expected custom rule to match")
+		}
+		if len(types) != 2 || types[0] != TypeString || types[1] != TypeInteger {
+			t.Errorf("unexpected types: %v", types)
+		}
+
+		// Built-in rules should still apply when the custom rule doesn't match.
+		hasMultipleTypes, _ = g.shouldSupportMultipleTypes("metadata.annotations")
+		if !hasMultipleTypes {
+			t.Error("expected built-in rule to still apply when merging")
+		}
+	})
+
+	t.Run("ReplacePatternRules", func(t *testing.T) {
+		g := NewGenerator(GeneratorOptions{
+			ReplacePatternRules: true,
+			PatternRules: []PatternRule{
+				{Path: "customfield", Match: "contains", CaseInsensitive: true, Types: []SchemaType{TypeString}},
+			},
+		})
+
+		// Built-in rule should no longer apply.
+		if hasMultipleTypes, _ := g.shouldSupportMultipleTypes("metadata.annotations"); hasMultipleTypes {
+			t.Error("expected built-in rules to be replaced")
+		}
+
+		if hasMultipleTypes, _ := g.shouldSupportMultipleTypes("app.customField"); !hasMultipleTypes {
+			t.Error("expected custom rule to match")
+		}
+	})
+
+	t.Run("NoCustomRulesMatchesPackageFunction", func(t *testing.T) {
+		g := NewGeneratorWithDefaults()
+		gotMulti, gotTypes := g.shouldSupportMultipleTypes("service.enabled")
+		wantMulti, wantTypes := shouldSupportMultipleTypes("service.enabled")
+		if gotMulti != wantMulti || len(gotTypes) != len(wantTypes) {
+			t.Errorf("generator method diverged from package function: got (%v, %v), want (%v, %v)",
+				gotMulti, gotTypes, wantMulti, wantTypes)
+		}
+	})
+}
+
+func TestNewGeneratorFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	rulesPath := filepath.Join(dir, "rules.yaml")
+	content := "- path: \"customfield\"\n  match: contains\n  types: [string, integer]\n"
+	if err := os.WriteFile(rulesPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write rules file: %v", err)
+	}
+
+	g, err := NewGeneratorFromConfig(DefaultOptions(), rulesPath)
+	if err != nil {
+		t.Fatalf("NewGeneratorFromConfig failed: %v", err)
+	}
+
+	if len(g.Options.PatternRules) != 1 || g.Options.PatternRules[0].Path != "customfield" {
+		t.Errorf("expected pattern rules to be loaded from file, got %+v", g.Options.PatternRules)
+	}
+
+	// Empty rulesPath should behave like NewGenerator.
+	g, err = NewGeneratorFromConfig(DefaultOptions(), "")
+	if err != nil {
+		t.Fatalf("NewGeneratorFromConfig with empty path failed: %v", err)
+	}
+	if len(g.Options.PatternRules) != 0 {
+		t.Errorf("expected no pattern rules, got %+v", g.Options.PatternRules)
+	}
+
+	if _, err := NewGeneratorFromConfig(DefaultOptions(), filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("expected error for missing rules file")
+	}
+}
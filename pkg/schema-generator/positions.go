@@ -0,0 +1,82 @@
+package jsonschema
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position is a 0-based line/column location in a source file, the
+// convention LSP and most editor protocols expect (yaml.v3's own
+// Node.Line/Node.Column are 1-based).
+type Position struct {
+	Line   int
+	Column int
+}
+
+// PositionIndex maps a dotted Helm path (see HelmPath in type_detection.go
+// and joinHelmPath in extract_comments.go for the "a.b" / "a[N]" convention)
+// to where that key or sequence item starts in the original YAML source.
+// BuildPositionIndex produces it; callers like pkg/lsp use it to turn a
+// ValidationIssue.Path into a precise editor range.
+type PositionIndex map[string]Position
+
+// BuildPositionIndex parses yamlData and walks it the same way
+// CommentExtractor.ExtractFromYAML walks it for comments, recording each
+// mapping key's (or sequence item's) starting position instead.
+func BuildPositionIndex(yamlData []byte) (PositionIndex, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(yamlData, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML for position index: %w", err)
+	}
+
+	index := PositionIndex{}
+	if len(doc.Content) == 0 {
+		return index, nil
+	}
+
+	walkPositions(doc.Content[0], "", index)
+	return index, nil
+}
+
+// walkPositions recurses into a mapping or sequence node, recording the
+// position of each child's key (or item) node at its dotted path.
+func walkPositions(node *yaml.Node, path string, index PositionIndex) {
+	if node == nil {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+			valueNode := node.Content[i+1]
+			childPath := joinHelmPath(path, keyNode.Value)
+
+			index[childPath] = Position{Line: keyNode.Line - 1, Column: keyNode.Column - 1}
+			walkPositions(valueNode, childPath, index)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+			index[itemPath] = Position{Line: item.Line - 1, Column: item.Column - 1}
+			walkPositions(item, itemPath, index)
+		}
+	}
+}
+
+// AttachPositions returns a copy of issues with Position filled in from
+// index for every issue whose Path resolves; issues whose path isn't found
+// (e.g. the root "" path, or a path synthesized by a check that doesn't
+// correspond to a literal YAML node) keep a zero Position.
+func AttachPositions(issues []ValidationIssue, index PositionIndex) []ValidationIssue {
+	resolved := make([]ValidationIssue, len(issues))
+	for i, issue := range issues {
+		resolved[i] = issue
+		if pos, ok := index[issue.Path]; ok {
+			resolved[i].Position = pos
+		}
+	}
+	return resolved
+}
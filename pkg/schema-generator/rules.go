@@ -0,0 +1,415 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Rule is a single best-practices check, evaluated against one schema node
+// at a time by RuleSet.Validate. path is the Helm path of s as RuleSet.walk
+// builds it: property names joined with ".", "[]" for array items, "[N]"
+// for tuple positions, "" for the schema root.
+type Rule interface {
+	ID() string
+	Check(path string, s *Schema) []ValidationIssue
+}
+
+// DescribedRule is a Rule that can describe itself for the "rules list"
+// subcommand and for RuleSet's severity-override/threshold configuration.
+// Every built-in rule implements it.
+type DescribedRule interface {
+	Rule
+	Description() string
+	DefaultLevel() ValidationLevel
+}
+
+// ThresholdRule is a DescribedRule with a single tunable integer
+// threshold (e.g. max-nesting-depth), settable via RuleSet.SetThreshold.
+type ThresholdRule interface {
+	DescribedRule
+	Threshold() int
+	SetThreshold(n int)
+}
+
+// RuleSet is an ordered, independently configurable collection of Rules.
+// Validate walks a schema depth-first, running every enabled rule at each
+// node and applying any configured severity override to the issues it
+// returns. The zero RuleSet has no rules; use NewRuleSet or
+// DefaultRuleSet to build one.
+type RuleSet struct {
+	rules      []Rule
+	disabled   map[string]bool
+	severities map[string]ValidationLevel
+}
+
+// NewRuleSet builds a RuleSet from rules, all enabled at their default
+// severity.
+func NewRuleSet(rules ...Rule) *RuleSet {
+	return &RuleSet{rules: rules, disabled: map[string]bool{}, severities: map[string]ValidationLevel{}}
+}
+
+// DefaultRuleSet returns the built-in rule set matching
+// ValidateHelmBestPractices' historical behavior: camelCase/separator
+// naming checks, a nesting-depth threshold, array-structure checks, and
+// documentation completeness checks.
+func DefaultRuleSet() *RuleSet {
+	return NewRuleSet(
+		&camelCaseRule{},
+		&separatorRule{},
+		&nestingDepthRule{maxDepth: MaxNestingDepth},
+		&arrayItemsRule{},
+		&arrayConstraintsRule{},
+		&descriptionRule{},
+		&examplesRule{},
+	)
+}
+
+// Rules returns every rule registered in rs, in registration order,
+// regardless of whether it's currently disabled.
+func (rs *RuleSet) Rules() []Rule {
+	return rs.rules
+}
+
+// Add registers an additional rule, e.g. a custom regex-based rule loaded
+// from a config file.
+func (rs *RuleSet) Add(rule Rule) {
+	rs.rules = append(rs.rules, rule)
+}
+
+// Disable turns off the rule with the given ID. Checking an unknown ID is
+// not an error - it simply has no effect - since config-driven disables
+// may reference a rule ID from a newer or older version of this package.
+func (rs *RuleSet) Disable(id string) {
+	if rs.disabled == nil {
+		rs.disabled = map[string]bool{}
+	}
+	rs.disabled[id] = true
+}
+
+// EnableOnly disables every rule whose ID is not in ids.
+func (rs *RuleSet) EnableOnly(ids []string) {
+	keep := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		keep[id] = true
+	}
+	for _, rule := range rs.rules {
+		if !keep[rule.ID()] {
+			rs.Disable(rule.ID())
+		}
+	}
+}
+
+// SetSeverity overrides the severity every issue from the rule with the
+// given ID is reported at, regardless of the level the rule itself sets.
+func (rs *RuleSet) SetSeverity(id string, level ValidationLevel) {
+	if rs.severities == nil {
+		rs.severities = map[string]ValidationLevel{}
+	}
+	rs.severities[id] = level
+}
+
+// SetThreshold sets the integer threshold (e.g. max-nesting-depth) of the
+// ThresholdRule with the given ID, if one is registered.
+func (rs *RuleSet) SetThreshold(id string, n int) {
+	for _, rule := range rs.rules {
+		if tr, ok := rule.(ThresholdRule); ok && tr.ID() == id {
+			tr.SetThreshold(n)
+		}
+	}
+}
+
+// Validate walks schema depth-first from the root, running every enabled
+// rule at each node and returning the combined, severity-adjusted issues.
+func (rs *RuleSet) Validate(schema *Schema) []ValidationIssue {
+	issues := []ValidationIssue{}
+	rs.walk(schema, "", &issues)
+	return issues
+}
+
+func (rs *RuleSet) walk(schema *Schema, path string, issues *[]ValidationIssue) {
+	for _, rule := range rs.rules {
+		if rs.disabled[rule.ID()] {
+			continue
+		}
+		for _, issue := range rule.Check(path, schema) {
+			if override, ok := rs.severities[rule.ID()]; ok {
+				issue.Level = override
+			}
+			*issues = append(*issues, issue)
+		}
+	}
+
+	if schema.Properties != nil {
+		for propName, propSchema := range schema.Properties {
+			propPath := propName
+			if path != "" {
+				propPath = path + "." + propName
+			}
+			rs.walk(propSchema, propPath, issues)
+		}
+	}
+
+	if (schema.Type == TypeArray || (schema.Type != nil && isTypeInArray(TypeArray, schema.Type))) && schema.Items != nil {
+		rs.walk(schema.Items, path+"[]", issues)
+
+		// Items itself may be a oneOf/anyOf of branch subschemas rather
+		// than a single subschema (see ArrayMixedOneOf/ArrayMixedAnyOf in
+		// array_mixed_strategy.go); walk each branch so naming/description
+		// rules still run on properties nested inside them instead of
+		// silently skipping that part of the tree.
+		for i, branch := range schema.Items.OneOf {
+			if branchSchema, ok := branch.(*Schema); ok {
+				rs.walk(branchSchema, fmt.Sprintf("%s[](oneOf:%d)", path, i), issues)
+			}
+		}
+		for i, branch := range schema.Items.AnyOf {
+			if branchSchema, ok := branch.(*Schema); ok {
+				rs.walk(branchSchema, fmt.Sprintf("%s[](anyOf:%d)", path, i), issues)
+			}
+		}
+	}
+
+	// TuplePrefixItems/TupleLegacyItems hold positional subschemas for
+	// ArrayMixedTupleValidation (see tuple_items.go) rather than going
+	// through Items, so they need their own recursion too.
+	for i, tupleItem := range schema.TuplePrefixItems {
+		rs.walk(tupleItem, fmt.Sprintf("%s[%d]", path, i), issues)
+	}
+	for i, tupleItem := range schema.TupleLegacyItems {
+		rs.walk(tupleItem, fmt.Sprintf("%s[%d]", path, i), issues)
+	}
+
+	// deduplicateSchemas (see dedup.go) replaces a repeated subschema's
+	// original node with a bare {"$ref": ...} stub and moves its real
+	// Properties into Defs/Definitions, so those hoisted definitions need
+	// their own recursion too or every best-practice rule silently stops
+	// checking that part of the tree once dedup fires.
+	for name, def := range schema.Defs {
+		rs.walk(def, fmt.Sprintf("$defs.%s", name), issues)
+	}
+	for name, def := range schema.Definitions {
+		rs.walk(def, fmt.Sprintf("definitions.%s", name), issues)
+	}
+}
+
+// ValidateHelmBestPractices checks schema against Helm best practices
+// using DefaultRuleSet. Callers that want to disable rules, override
+// severities, or add custom rules should build their own RuleSet (see
+// DefaultRuleSet) and call its Validate method instead.
+func ValidateHelmBestPractices(schema *Schema) []ValidationIssue {
+	return DefaultRuleSet().Validate(schema)
+}
+
+// camelCaseRule flags object property names whose first character is an
+// upper-case letter.
+type camelCaseRule struct{}
+
+func (camelCaseRule) ID() string                    { return "naming/camelcase" }
+func (camelCaseRule) Description() string           { return "Property names should follow camelCase convention" }
+func (camelCaseRule) DefaultLevel() ValidationLevel { return Warning }
+
+func (r camelCaseRule) Check(path string, s *Schema) []ValidationIssue {
+	if s.Properties == nil || path == "" {
+		return nil
+	}
+	var issues []ValidationIssue
+	for propName := range s.Properties {
+		if strings.ToLower(propName[:1]) != propName[:1] {
+			issues = append(issues, ValidationIssue{
+				Path:    path + "." + propName,
+				Message: r.Description(),
+				Level:   r.DefaultLevel(),
+			})
+		}
+	}
+	return issues
+}
+
+// separatorRule flags object property names containing hyphens or
+// underscores, which don't round-trip cleanly through Helm's dot-path
+// value overrides (--set foo-bar=1).
+type separatorRule struct{}
+
+func (separatorRule) ID() string { return "naming/separator" }
+func (separatorRule) Description() string {
+	return "Property names should not contain hyphens or underscores"
+}
+func (separatorRule) DefaultLevel() ValidationLevel { return Error }
+
+func (r separatorRule) Check(path string, s *Schema) []ValidationIssue {
+	if s.Properties == nil || path == "" {
+		return nil
+	}
+	var issues []ValidationIssue
+	for propName := range s.Properties {
+		if strings.Contains(propName, "-") || strings.Contains(propName, "_") {
+			issues = append(issues, ValidationIssue{
+				Path:    path + "." + propName,
+				Message: r.Description(),
+				Level:   r.DefaultLevel(),
+			})
+		}
+	}
+	return issues
+}
+
+// nestingDepthRule flags schema nodes nested deeper than maxDepth. Depth
+// is derived from path, since Rule.Check doesn't carry recursion state:
+// each "." and each "[]" segment represents one level of nesting below
+// the root.
+type nestingDepthRule struct {
+	maxDepth int
+}
+
+func (nestingDepthRule) ID() string { return "structure/nesting-depth" }
+func (nestingDepthRule) Description() string {
+	return "Flags schemas nested deeper than the configured threshold"
+}
+func (nestingDepthRule) DefaultLevel() ValidationLevel { return Warning }
+func (r *nestingDepthRule) Threshold() int             { return r.maxDepth }
+func (r *nestingDepthRule) SetThreshold(n int)         { r.maxDepth = n }
+
+func (r *nestingDepthRule) Check(path string, _ *Schema) []ValidationIssue {
+	depth := pathDepth(path)
+	if depth <= r.maxDepth {
+		return nil
+	}
+	return []ValidationIssue{{
+		Path:    path,
+		Message: fmt.Sprintf("Excessive nesting depth (%d levels). Consider flattening the structure or using dot notation for paths.", depth),
+		Level:   r.DefaultLevel(),
+	}}
+}
+
+// pathDepth returns the number of nesting levels a Helm path built by
+// RuleSet.walk represents below the schema root, which has depth 0.
+func pathDepth(path string) int {
+	if path == "" {
+		return 0
+	}
+	return 1 + strings.Count(path, ".") + strings.Count(path, "[]")
+}
+
+// arrayItemsRule flags arrays with no items schema, which can't validate
+// their elements.
+type arrayItemsRule struct{}
+
+func (arrayItemsRule) ID() string { return "structure/array-items" }
+func (arrayItemsRule) Description() string {
+	return "Array should define an items schema for validation"
+}
+func (arrayItemsRule) DefaultLevel() ValidationLevel { return Warning }
+
+func (r arrayItemsRule) Check(path string, s *Schema) []ValidationIssue {
+	isArray := s.Type == TypeArray || (s.Type != nil && isTypeInArray(TypeArray, s.Type))
+	if !isArray || s.Items != nil {
+		return nil
+	}
+	return []ValidationIssue{{Path: path, Message: r.Description(), Level: r.DefaultLevel()}}
+}
+
+// arrayConstraintsRule suggests minItems/maxItems on arrays whose path
+// suggests sensitive or bounded content (secrets, config, certificates).
+type arrayConstraintsRule struct{}
+
+func (arrayConstraintsRule) ID() string { return "structure/array-constraints" }
+func (arrayConstraintsRule) Description() string {
+	return "Consider adding minItems/maxItems constraints for this array"
+}
+func (arrayConstraintsRule) DefaultLevel() ValidationLevel { return Info }
+
+func (r arrayConstraintsRule) Check(path string, s *Schema) []ValidationIssue {
+	isArray := s.Type == TypeArray || (s.Type != nil && isTypeInArray(TypeArray, s.Type))
+	if !isArray || path == "" {
+		return nil
+	}
+	if !strings.Contains(path, "secret") && !strings.Contains(path, "config") && !strings.Contains(path, "certificate") {
+		return nil
+	}
+	if s.MinItems != nil || s.MaxItems != nil {
+		return nil
+	}
+	return []ValidationIssue{{Path: path, Message: r.Description(), Level: r.DefaultLevel()}}
+}
+
+// descriptionRule flags properties with no description, at Warning rather
+// than Info for paths with at most one "." (top-level properties and their
+// direct children).
+type descriptionRule struct{}
+
+func (descriptionRule) ID() string                    { return "docs/description" }
+func (descriptionRule) Description() string           { return "Property should have a description" }
+func (descriptionRule) DefaultLevel() ValidationLevel { return Info }
+
+func (r descriptionRule) Check(path string, s *Schema) []ValidationIssue {
+	if path == "" || s.Description != "" {
+		return nil
+	}
+	level := r.DefaultLevel()
+	if strings.Count(path, ".") <= 1 {
+		level = Warning
+	}
+	return []ValidationIssue{{Path: path, Message: r.Description(), Level: level}}
+}
+
+// examplesRule flags leaf properties (no nested properties, not an
+// object/array) with no examples or default value.
+type examplesRule struct{}
+
+func (examplesRule) ID() string                    { return "docs/examples" }
+func (examplesRule) Description() string           { return "Consider adding examples or default value" }
+func (examplesRule) DefaultLevel() ValidationLevel { return Info }
+
+func (r examplesRule) Check(path string, s *Schema) []ValidationIssue {
+	if path == "" {
+		return nil
+	}
+	isLeaf := len(s.Properties) == 0
+	if !isLeaf || s.Examples != nil || s.Default != nil || s.Type == TypeObject || s.Type == TypeArray {
+		return nil
+	}
+	return []ValidationIssue{{Path: path, Message: r.Description(), Level: r.DefaultLevel()}}
+}
+
+// RegexPropertyRule is a user-defined rule, built from a config file's
+// inline custom-rule entry, that flags object properties whose name
+// matches pattern.
+type RegexPropertyRule struct {
+	id      string
+	pattern *regexp.Regexp
+	message string
+	level   ValidationLevel
+}
+
+// NewRegexPropertyRule compiles pattern and returns a Rule that reports
+// message at level for every object property name it matches.
+func NewRegexPropertyRule(id, pattern, message string, level ValidationLevel) (*RegexPropertyRule, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern for custom rule %q: %w", id, err)
+	}
+	return &RegexPropertyRule{id: id, pattern: re, message: message, level: level}, nil
+}
+
+func (r *RegexPropertyRule) ID() string                    { return r.id }
+func (r *RegexPropertyRule) Description() string           { return r.message }
+func (r *RegexPropertyRule) DefaultLevel() ValidationLevel { return r.level }
+
+func (r *RegexPropertyRule) Check(path string, s *Schema) []ValidationIssue {
+	if s.Properties == nil {
+		return nil
+	}
+	var issues []ValidationIssue
+	for propName := range s.Properties {
+		if !r.pattern.MatchString(propName) {
+			continue
+		}
+		propPath := propName
+		if path != "" {
+			propPath = path + "." + propName
+		}
+		issues = append(issues, ValidationIssue{Path: propPath, Message: r.message, Level: r.level})
+	}
+	return issues
+}
@@ -0,0 +1,233 @@
+package jsonschema
+
+import (
+	"testing"
+)
+
+func TestRuleSetDisable(t *testing.T) {
+	schema := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"badName": {Type: TypeString, Description: "fine", Examples: []any{"x"}},
+		},
+	}
+
+	rs := DefaultRuleSet()
+	rs.Disable("naming/separator")
+
+	issues := rs.Validate(schema)
+	for _, issue := range issues {
+		if issue.Message == "Property names should not contain hyphens or underscores" {
+			t.Errorf("expected naming/separator to be disabled, but found its issue: %+v", issue)
+		}
+	}
+}
+
+func TestRuleSetEnableOnly(t *testing.T) {
+	// naming rules only fire on a non-root path (see camelCaseRule and
+	// separatorRule), so the bad property name needs to be nested one
+	// level deep to trigger.
+	schema := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"nested": {
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"with-hyphen": {Type: TypeString},
+				},
+			},
+		},
+	}
+
+	rs := DefaultRuleSet()
+	rs.EnableOnly([]string{"naming/separator"})
+
+	issues := rs.Validate(schema)
+	for _, issue := range issues {
+		if issue.Path != "nested.with-hyphen" {
+			t.Errorf("expected only naming/separator's issue, got: %+v", issue)
+		}
+	}
+	if len(issues) == 0 {
+		t.Fatal("expected at least the naming/separator issue")
+	}
+}
+
+func TestRuleSetSetSeverity(t *testing.T) {
+	schema := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"nested": {
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"BadName": {Type: TypeString, Description: "fine", Examples: []any{"x"}},
+				},
+			},
+		},
+	}
+
+	rs := DefaultRuleSet()
+	rs.SetSeverity("naming/camelcase", Error)
+
+	issues := rs.Validate(schema)
+	found := false
+	for _, issue := range issues {
+		if issue.Message == "Property names should follow camelCase convention" {
+			found = true
+			if issue.Level != Error {
+				t.Errorf("expected overridden severity Error, got %s", issue.Level)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected a naming/camelcase issue")
+	}
+}
+
+func TestRuleSetSetThreshold(t *testing.T) {
+	schema := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"a": {
+				Type:        TypeObject,
+				Description: "x",
+				Properties: map[string]*Schema{
+					"b": {Type: TypeString, Description: "x", Examples: []any{"x"}},
+				},
+			},
+		},
+	}
+
+	rs := DefaultRuleSet()
+	rs.SetThreshold("structure/nesting-depth", 1)
+
+	issues := rs.Validate(schema)
+	found := false
+	for _, issue := range issues {
+		if issue.Path == "a.b" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected a nesting-depth issue at a.b with the threshold lowered to 1")
+	}
+}
+
+func TestRegexPropertyRule(t *testing.T) {
+	rule, err := NewRegexPropertyRule("custom/no-todo", "(?i)todo", "property name should not reference TODO", Warning)
+	if err != nil {
+		t.Fatalf("NewRegexPropertyRule returned an error: %v", err)
+	}
+
+	rs := NewRuleSet(rule)
+	schema := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"todoList": {Type: TypeArray},
+		},
+	}
+
+	issues := rs.Validate(schema)
+	if len(issues) != 1 || issues[0].Path != "todoList" {
+		t.Fatalf("expected one issue for todoList, got %+v", issues)
+	}
+}
+
+func TestRuleSetWalksOneOfAnyOfAndTupleBranches(t *testing.T) {
+	schema := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"oneOfItems": {
+				Type: TypeArray,
+				Items: &Schema{
+					OneOf: []any{
+						&Schema{Type: TypeObject, Properties: map[string]*Schema{"with-hyphen": {Type: TypeString}}},
+					},
+				},
+			},
+			"anyOfItems": {
+				Type: TypeArray,
+				Items: &Schema{
+					AnyOf: []any{
+						&Schema{Type: TypeObject, Properties: map[string]*Schema{"with_underscore": {Type: TypeString}}},
+					},
+				},
+			},
+			"tupleItems": {
+				Type:             TypeArray,
+				TuplePrefixItems: []*Schema{{Type: TypeObject, Properties: map[string]*Schema{"bad-name": {Type: TypeString}}}},
+			},
+		},
+	}
+
+	rs := DefaultRuleSet()
+	rs.EnableOnly([]string{"naming/separator"})
+
+	issues := rs.Validate(schema)
+	wantPaths := map[string]bool{
+		"oneOfItems[](oneOf:0).with-hyphen":     true,
+		"anyOfItems[](anyOf:0).with_underscore": true,
+		"tupleItems[0].bad-name":                true,
+	}
+	gotPaths := map[string]bool{}
+	for _, issue := range issues {
+		gotPaths[issue.Path] = true
+	}
+	for path := range wantPaths {
+		if !gotPaths[path] {
+			t.Errorf("expected an issue at %q (rules should run inside oneOf/anyOf/tuple branches), got issues: %+v", path, issues)
+		}
+	}
+}
+
+func TestRuleSetWalksDefsAndDefinitions(t *testing.T) {
+	schema := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"container": {Ref: "#/definitions/container"},
+			"sidecar":   {Ref: "#/definitions/container"},
+		},
+		Definitions: map[string]*Schema{
+			"container": {
+				Type:       TypeObject,
+				Properties: map[string]*Schema{"with-hyphen": {Type: TypeString}},
+			},
+		},
+	}
+
+	rs := DefaultRuleSet()
+	rs.EnableOnly([]string{"naming/separator"})
+
+	issues := rs.Validate(schema)
+	for _, issue := range issues {
+		if issue.Path == "definitions.container.with-hyphen" {
+			return
+		}
+	}
+	t.Fatalf("expected naming/separator to run inside a hoisted definitions entry, got issues: %+v", issues)
+}
+
+func TestValidateHelmBestPracticesUsesDefaultRuleSet(t *testing.T) {
+	schema := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"nested": {
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"with-hyphen": {Type: TypeString},
+				},
+			},
+		},
+	}
+
+	issues := ValidateHelmBestPractices(schema)
+	found := false
+	for _, issue := range issues {
+		if issue.Message == "Property names should not contain hyphens or underscores" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected ValidateHelmBestPractices to still run the naming/separator rule by default")
+	}
+}
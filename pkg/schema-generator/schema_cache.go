@@ -0,0 +1,259 @@
+package jsonschema
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dc-tec/helm-schema-gen/pkg/logging"
+)
+
+// SchemaCache persists generated schemas to disk, keyed by the SHA-256 of
+// the input YAML bytes plus a hash of the GeneratorOptions that produced
+// them, so that re-running generation over an unchanged file with the same
+// options can skip parsing and inference entirely. This is the cache layer
+// the CLI's --cache-dir/--no-cache flags sit on top of (see pkg/cli). The
+// same Dir also roots the per-subtree entries GenerateFromYAMLIncremental
+// writes, each under its own chart-hash subdirectory.
+type SchemaCache struct {
+	// Dir is the directory cache entries are read from and written to.
+	Dir string
+
+	// MaxAge, when non-zero, treats an entry older than MaxAge as a miss
+	// rather than serving it, so a cache can self-heal from staleness
+	// without the caller having to clear it out by hand.
+	MaxAge time.Duration
+
+	// Hits and Misses count cache lookups for the caller to report, e.g. via
+	// the logging package: Get calls, and GenerateFromYAMLIncremental's
+	// per-key lookups.
+	Hits   int
+	Misses int
+}
+
+// NewSchemaCache returns a SchemaCache rooted at dir. It does not create dir;
+// that happens lazily on the first Put.
+func NewSchemaCache(dir string) *SchemaCache {
+	return &SchemaCache{Dir: dir}
+}
+
+// DefaultCacheDir resolves the default cache directory, honoring
+// $XDG_CACHE_HOME when set and falling back to os.UserCacheDir() otherwise.
+func DefaultCacheDir() (string, error) {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "helm-schema-gen"), nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve user cache directory: %w", err)
+	}
+	return filepath.Join(base, "helm-schema-gen"), nil
+}
+
+// cacheKey combines a content hash (the input YAML bytes) with an options
+// hash (the GeneratorOptions that will process them) into the cache entry's
+// file name, so changing either invalidates the entry.
+func cacheKey(yamlData []byte, options GeneratorOptions) (string, error) {
+	optionsHash, err := hashGeneratorOptions(options)
+	if err != nil {
+		return "", err
+	}
+	contentSum := sha256.Sum256(yamlData)
+	return hex.EncodeToString(contentSum[:]) + "-" + optionsHash, nil
+}
+
+// cacheableOptions mirrors the GeneratorOptions fields that influence
+// generation output. EnvProvider is an interface value and is deliberately
+// excluded, since it can't be serialized; the CLI never sets it.
+type cacheableOptions struct {
+	SchemaVersion                 SchemaVersion
+	Title                         string
+	Description                   string
+	RequireByDefault              bool
+	IncludeExamples               bool
+	ExtractDescriptions           bool
+	UseFullyQualifiedIDs          bool
+	PatternRules                  []PatternRule
+	ReplacePatternRules           bool
+	MaxEnumValues                 int
+	DedupThreshold                int
+	DedupMinProperties            int
+	InferTuples                   bool
+	DisallowUnevaluatedProperties bool
+	InterpolateEnvVars            bool
+	ConcatSequencesOnMerge        bool
+	EmitCELValidations            bool
+	MaxRuleCost                   int
+	ArrayInference                ArrayInferenceMode
+	SpecializeForHelm             bool
+	DisabledRecognizers           []string
+	EnabledFormats                []string
+	ArrayMixedStrategy            ArrayMixedStrategy
+	MergeStrategy                 MergeStrategy
+}
+
+func hashGeneratorOptions(options GeneratorOptions) (string, error) {
+	encoded, err := json.Marshal(cacheableOptions{
+		SchemaVersion:                 options.SchemaVersion,
+		Title:                         options.Title,
+		Description:                   options.Description,
+		RequireByDefault:              options.RequireByDefault,
+		IncludeExamples:               options.IncludeExamples,
+		ExtractDescriptions:           options.ExtractDescriptions,
+		UseFullyQualifiedIDs:          options.UseFullyQualifiedIDs,
+		PatternRules:                  options.PatternRules,
+		ReplacePatternRules:           options.ReplacePatternRules,
+		MaxEnumValues:                 options.MaxEnumValues,
+		DedupThreshold:                options.DedupThreshold,
+		DedupMinProperties:            options.DedupMinProperties,
+		InferTuples:                   options.InferTuples,
+		DisallowUnevaluatedProperties: options.DisallowUnevaluatedProperties,
+		InterpolateEnvVars:            options.InterpolateEnvVars,
+		ConcatSequencesOnMerge:        options.ConcatSequencesOnMerge,
+		EmitCELValidations:            options.EmitCELValidations,
+		MaxRuleCost:                   options.MaxRuleCost,
+		ArrayInference:                options.ArrayInference,
+		SpecializeForHelm:             options.SpecializeForHelm,
+		DisabledRecognizers:           options.DisabledRecognizers,
+		EnabledFormats:                options.EnabledFormats,
+		ArrayMixedStrategy:            options.ArrayMixedStrategy,
+		MergeStrategy:                 options.MergeStrategy,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash generator options: %w", err)
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func (c *SchemaCache) entryPath(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// Get looks up a previously cached schema for the given input YAML and
+// options. A missing, unreadable, or (per MaxAge) stale entry is reported
+// as a cache miss rather than an error, since the caller should simply fall
+// back to generating.
+func (c *SchemaCache) Get(ctx context.Context, yamlData []byte, options GeneratorOptions) (*Schema, bool) {
+	logger := logging.WithComponent(ctx, "json-schema-generator")
+
+	key, err := cacheKey(yamlData, options)
+	if err != nil {
+		logger.WarnContext(ctx, "failed to compute schema cache key, treating as a miss", "error", err)
+		c.Misses++
+		return nil, false
+	}
+
+	schema, ok := readCacheEntry(ctx, logger, c.entryPath(key), c.MaxAge)
+	if !ok {
+		c.Misses++
+		return nil, false
+	}
+
+	c.Hits++
+	return schema, true
+}
+
+// Put writes schema to the cache entry for the given input YAML and
+// options, creating the cache directory if needed.
+func (c *SchemaCache) Put(ctx context.Context, yamlData []byte, options GeneratorOptions, schema *Schema) error {
+	key, err := cacheKey(yamlData, options)
+	if err != nil {
+		return err
+	}
+	return writeCacheEntry(c.Dir, c.entryPath(key), schema)
+}
+
+// readCacheEntry reads and decodes the cache entry at path, reporting a
+// miss (false) if it's absent, unreadable, malformed, or older than maxAge
+// (when maxAge is non-zero).
+func readCacheEntry(ctx context.Context, logger *slog.Logger, path string, maxAge time.Duration) (*Schema, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if maxAge > 0 && time.Since(info.ModTime()) > maxAge {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- path is built from a hex digest we generated
+	if err != nil {
+		return nil, false
+	}
+
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		logger.WarnContext(ctx, "failed to decode cached schema, treating as a miss", "error", err, "path", path)
+		return nil, false
+	}
+
+	return &schema, true
+}
+
+// writeCacheEntry writes schema to path, creating dir if needed. The write
+// is atomic: it writes to a temp file in dir and renames it into place, so
+// a concurrent reader never observes a partially written entry.
+func writeCacheEntry(dir, path string, schema *Schema) error {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("failed to create schema cache directory: %w", err)
+	}
+
+	encoded, err := json.Marshal(schema)
+	if err != nil {
+		return fmt.Errorf("failed to encode schema for caching: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary schema cache file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(encoded); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write schema cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write schema cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to finalize schema cache entry: %w", err)
+	}
+	return nil
+}
+
+// GenerateFromYAMLCached behaves like GenerateFromYAML, but first consults
+// cache for a schema generated from identical YAML bytes under identical
+// options, skipping parsing and inference entirely on a hit. On a miss, it
+// generates normally and writes the result back to cache. A cached entry is
+// decoded through Schema's UnmarshalJSON, which restores tuple items and
+// Placeholder; a decoded Type holds a plain string (or []any) rather than a
+// SchemaType, and HelmPath, which is not serialized, comes back empty.
+func (g *Generator) GenerateFromYAMLCached(ctx context.Context, yamlData []byte, cache *SchemaCache) (*Schema, error) {
+	logger := logging.WithComponent(ctx, "json-schema-generator")
+
+	if schema, ok := cache.Get(ctx, yamlData, g.Options); ok {
+		logger.InfoContext(ctx, "schema cache hit, skipping generation")
+		return schema, nil
+	}
+	logger.InfoContext(ctx, "schema cache miss, generating")
+
+	schema, err := g.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cache.Put(ctx, yamlData, g.Options, schema); err != nil {
+		logger.WarnContext(ctx, "failed to write schema cache entry", "error", err)
+	}
+
+	return schema, nil
+}
@@ -0,0 +1,156 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSchemaCache_MissThenHit(t *testing.T) {
+	ctx := context.Background()
+	cache := NewSchemaCache(t.TempDir())
+
+	options := GeneratorOptions{SchemaVersion: Draft07}
+	yamlData := []byte("replicaCount: 1\n")
+
+	if _, ok := cache.Get(ctx, yamlData, options); ok {
+		t.Fatal("expected a miss on an empty cache")
+	}
+	if cache.Misses != 1 || cache.Hits != 0 {
+		t.Errorf("expected 1 miss and 0 hits, got misses=%d hits=%d", cache.Misses, cache.Hits)
+	}
+
+	generator := NewGenerator(options)
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	if err := cache.Put(ctx, yamlData, options, schema); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	cached, ok := cache.Get(ctx, yamlData, options)
+	if !ok {
+		t.Fatal("expected a hit after Put")
+	}
+	if cache.Hits != 1 {
+		t.Errorf("expected 1 hit, got %d", cache.Hits)
+	}
+	if _, hasProp := cached.Properties["replicaCount"]; !hasProp {
+		t.Errorf("expected the cached schema to round-trip its properties, got %+v", cached.Properties)
+	}
+}
+
+func TestSchemaCache_DifferentOptionsMiss(t *testing.T) {
+	ctx := context.Background()
+	cache := NewSchemaCache(t.TempDir())
+	yamlData := []byte("replicaCount: 1\n")
+
+	optionsA := GeneratorOptions{SchemaVersion: Draft07}
+	optionsB := GeneratorOptions{SchemaVersion: Draft07, RequireByDefault: true}
+
+	schema, err := NewGenerator(optionsA).GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+	if err := cache.Put(ctx, yamlData, optionsA, schema); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	if _, ok := cache.Get(ctx, yamlData, optionsB); ok {
+		t.Error("expected a miss when the same file is looked up under different options")
+	}
+}
+
+func TestSchemaCache_DifferentContentMiss(t *testing.T) {
+	ctx := context.Background()
+	cache := NewSchemaCache(t.TempDir())
+	options := GeneratorOptions{SchemaVersion: Draft07}
+
+	schema, err := NewGenerator(options).GenerateFromYAML(ctx, []byte("replicaCount: 1\n"))
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+	if err := cache.Put(ctx, []byte("replicaCount: 1\n"), options, schema); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	if _, ok := cache.Get(ctx, []byte("replicaCount: 2\n"), options); ok {
+		t.Error("expected a miss when the input bytes differ")
+	}
+}
+
+func TestSchemaCache_RoundTripsTupleItems(t *testing.T) {
+	ctx := context.Background()
+	cache := NewSchemaCache(t.TempDir())
+
+	options := DefaultOptions()
+	options.InferTuples = true
+	options.ExtractDescriptions = false
+	yamlData := []byte("pair:\n  - myservice\n  - 8080\n")
+
+	schema, err := NewGenerator(options).GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+	pair := schema.Properties["pair"]
+	if len(pair.TupleLegacyItems) != 2 {
+		t.Fatalf("expected the fixture to produce 2 legacy tuple items, got %d", len(pair.TupleLegacyItems))
+	}
+
+	if err := cache.Put(ctx, yamlData, options, schema); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	cached, ok := cache.Get(ctx, yamlData, options)
+	if !ok {
+		t.Fatal("expected a hit after Put for a tuple-containing schema")
+	}
+
+	cachedPair := cached.Properties["pair"]
+	if cachedPair == nil {
+		t.Fatal("expected the cached schema to still have a pair property")
+	}
+	if cachedPair.Items != nil {
+		t.Errorf("expected Items to stay unset for a restored tuple, got %+v", cachedPair.Items)
+	}
+	if len(cachedPair.TupleLegacyItems) != 2 {
+		t.Fatalf("expected the cached schema to restore 2 legacy tuple items, got %d", len(cachedPair.TupleLegacyItems))
+	}
+	// Compared against the raw JSON string rather than the SchemaType
+	// constant: once Type has round-tripped through json.Unmarshal into
+	// the any-typed field, its dynamic type is string, not SchemaType.
+	if cachedPair.TupleLegacyItems[0].Type != string(TypeString) {
+		t.Errorf("expected restored position 0 to be string, got %v", cachedPair.TupleLegacyItems[0].Type)
+	}
+	if cachedPair.TupleLegacyItems[1].Type != string(TypeInteger) {
+		t.Errorf("expected restored position 1 to be integer, got %v", cachedPair.TupleLegacyItems[1].Type)
+	}
+}
+
+func TestGenerateFromYAMLCached_SkipsRegenerationOnHit(t *testing.T) {
+	ctx := context.Background()
+	cache := NewSchemaCache(t.TempDir())
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+	yamlData := []byte("replicaCount: 1\n")
+
+	first, err := generator.GenerateFromYAMLCached(ctx, yamlData, cache)
+	if err != nil {
+		t.Fatalf("GenerateFromYAMLCached failed: %v", err)
+	}
+	if cache.Misses != 1 || cache.Hits != 0 {
+		t.Errorf("expected a miss on first call, got misses=%d hits=%d", cache.Misses, cache.Hits)
+	}
+
+	second, err := generator.GenerateFromYAMLCached(ctx, yamlData, cache)
+	if err != nil {
+		t.Fatalf("GenerateFromYAMLCached failed: %v", err)
+	}
+	if cache.Hits != 1 {
+		t.Errorf("expected a hit on second call, got hits=%d", cache.Hits)
+	}
+	if _, ok := second.Properties["replicaCount"]; !ok {
+		t.Errorf("expected the cached result to still expose replicaCount, got %+v", second.Properties)
+	}
+	_ = first
+}
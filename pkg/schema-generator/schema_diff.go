@@ -0,0 +1,78 @@
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DiffSchemaPaths compares old and current and returns the dotted Helm
+// paths (see inferSchema/HelmPath for the path convention, with array items
+// suffixed "[]") added, removed, or changed between them. A path is
+// "changed" if it exists in both but its own type, description, format,
+// pattern, default, or enum differs; differences confined to a nested path are reported
+// under that nested path instead. All three slices are sorted.
+func DiffSchemaPaths(old, current *Schema) (added, removed, changed []string) {
+	oldPaths := collectSchemaPaths(old)
+	newPaths := collectSchemaPaths(current)
+
+	for path, newSchema := range newPaths {
+		oldSchema, existed := oldPaths[path]
+		if !existed {
+			added = append(added, path)
+			continue
+		}
+		if !schemaOwnFieldsEqual(oldSchema, newSchema) {
+			changed = append(changed, path)
+		}
+	}
+	for path := range oldPaths {
+		if _, stillPresent := newPaths[path]; !stillPresent {
+			removed = append(removed, path)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	return added, removed, changed
+}
+
+// collectSchemaPaths flattens schema into a map from dotted path to the
+// *Schema node at that path, recursing into Properties and Items.
+func collectSchemaPaths(schema *Schema) map[string]*Schema {
+	paths := map[string]*Schema{}
+	var walk func(s *Schema, path string)
+	walk = func(s *Schema, path string) {
+		if s == nil {
+			return
+		}
+		if path != "" {
+			paths[path] = s
+		}
+		for name, prop := range s.Properties {
+			childPath := name
+			if path != "" {
+				childPath = path + "." + name
+			}
+			walk(prop, childPath)
+		}
+		if s.Items != nil {
+			walk(s.Items, path+"[]")
+		}
+	}
+	walk(schema, "")
+	return paths
+}
+
+// schemaOwnFieldsEqual reports whether a and b agree on the fields that
+// describe a single path's own declaration, ignoring nested Properties and
+// Items so a child's change is reported under its own path rather than
+// bubbling up to every ancestor.
+func schemaOwnFieldsEqual(a, b *Schema) bool {
+	return fmt.Sprint(a.Type) == fmt.Sprint(b.Type) &&
+		a.Description == b.Description &&
+		a.Format == b.Format &&
+		a.Pattern == b.Pattern &&
+		fmt.Sprint(a.Default) == fmt.Sprint(b.Default) &&
+		fmt.Sprint(a.Enum) == fmt.Sprint(b.Enum)
+}
@@ -0,0 +1,68 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSchemaPaths(t *testing.T) {
+	old := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"replicaCount": {Type: TypeInteger},
+			"image": {
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"repository": {Type: TypeString, Default: "nginx"},
+					"tag":        {Type: TypeString},
+				},
+			},
+		},
+	}
+
+	current := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"replicaCount": {Type: TypeInteger},
+			"image": {
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"repository": {Type: TypeString, Default: "alpine"},
+				},
+			},
+			"service": {Type: TypeObject},
+		},
+	}
+
+	added, removed, changed := DiffSchemaPaths(old, current)
+
+	if !reflect.DeepEqual(added, []string{"service"}) {
+		t.Errorf("expected added=[service], got %v", added)
+	}
+	if !reflect.DeepEqual(removed, []string{"image.tag"}) {
+		t.Errorf("expected removed=[image.tag], got %v", removed)
+	}
+	if !reflect.DeepEqual(changed, []string{"image.repository"}) {
+		t.Errorf("expected changed=[image.repository], got %v", changed)
+	}
+}
+
+func TestDiffSchemaPaths_ArrayItems(t *testing.T) {
+	old := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"ports": {Type: TypeArray, Items: &Schema{Type: TypeInteger}},
+		},
+	}
+	current := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"ports": {Type: TypeArray, Items: &Schema{Type: TypeString}},
+		},
+	}
+
+	_, _, changed := DiffSchemaPaths(old, current)
+	if !reflect.DeepEqual(changed, []string{"ports[]"}) {
+		t.Errorf("expected changed=[ports[]], got %v", changed)
+	}
+}
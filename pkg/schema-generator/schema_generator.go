@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/dc-tec/helm-schema-gen/pkg/logging"
-	"gopkg.in/yaml.v2"
 )
 
 // GenerateFromYAML generates a JSON schema from YAML data.
@@ -16,25 +15,23 @@ func (g *Generator) GenerateFromYAML(ctx context.Context, yamlData []byte) (*Sch
 	logger := logging.WithComponent(ctx, "json-schema-generator")
 	logger.InfoContext(ctx, "generating schema from YAML data")
 
-	// Parse YAML into a map
-	var data any
-	if err := yaml.Unmarshal(yamlData, &data); err != nil {
-		logger.ErrorContext(ctx, "failed to unmarshal YAML", "error", err)
-		return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
+	var interpolated []interpolationResult
+	if g.Options.InterpolateEnvVars {
+		substituted, results, err := interpolateEnvVars(yamlData, g.Options.EnvProvider)
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to interpolate environment variables", "error", err)
+			return nil, fmt.Errorf("failed to interpolate environment variables: %w", err)
+		}
+		yamlData = substituted
+		interpolated = results
 	}
 
-	// Convert YAML map[any]any to map[string]any
-	mappedData, err := convertYAMLToStringMap(data)
+	// Parse YAML into a map, merging every "---"-separated document it
+	// contains (a plain single-document file merges trivially into itself)
+	dataMap, err := parseYAMLDocuments(yamlData, g.Options.ConcatSequencesOnMerge)
 	if err != nil {
-		logger.ErrorContext(ctx, "failed to convert YAML", "error", err)
-		return nil, fmt.Errorf("failed to convert YAML: %w", err)
-	}
-
-	// Cast to map[string]any
-	dataMap, ok := mappedData.(map[string]any)
-	if !ok {
-		logger.ErrorContext(ctx, "root YAML value must be a map", "type", fmt.Sprintf("%T", mappedData))
-		return nil, fmt.Errorf("root YAML value must be a map, got %T", mappedData)
+		logger.ErrorContext(ctx, "failed to unmarshal YAML", "error", err)
+		return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
 	}
 
 	// Generate schema from the parsed data
@@ -44,6 +41,8 @@ func (g *Generator) GenerateFromYAML(ctx context.Context, yamlData []byte) (*Sch
 		return nil, fmt.Errorf("failed to generate schema: %w", err)
 	}
 
+	applyInterpolationHints(schema, interpolated)
+
 	// Extract and apply comments if enabled
 	if g.Options.ExtractDescriptions {
 		logger.InfoContext(ctx, "extracting descriptions from comments")
@@ -54,7 +53,7 @@ func (g *Generator) GenerateFromYAML(ctx context.Context, yamlData []byte) (*Sch
 			commentExtractor.Debug = true
 		}
 
-		commentExtractor.ExtractFromYAML(yamlData)
+		commentExtractor.ExtractFromYAML(ctx, yamlData)
 
 		// Print all comments when in debug mode
 		if g.Options.Debug {
@@ -107,17 +106,7 @@ func convertYAMLToStringMap(i any) (any, error) {
 
 // GenerateFromMap generates a JSON schema from a map.
 func (g *Generator) GenerateFromMap(ctx context.Context, data map[string]any) (*Schema, error) {
-	// Create a root schema
-	rootSchema := &Schema{
-		Schema:      g.Options.SchemaVersion,
-		Title:       g.Options.Title,
-		Description: g.Options.Description,
-		Type:        TypeObject,
-		Properties:  make(map[string]*Schema),
-	}
-
-	// Track required properties
-	var required []string
+	rootSchema := g.newRootSchema()
 
 	// Process each property in the map
 	for key, value := range data {
@@ -126,20 +115,55 @@ func (g *Generator) GenerateFromMap(ctx context.Context, data map[string]any) (*
 			return nil, fmt.Errorf("failed to infer schema for property '%s': %w", key, err)
 		}
 
-		rootSchema.Properties[key] = propSchema
+		g.addProperty(rootSchema, key, value, propSchema)
+	}
 
-		// Add to required list if enabled and value is non-nil
-		if g.Options.RequireByDefault && value != nil {
-			required = append(required, key)
-		}
+	g.finalizeRootSchema(rootSchema)
+
+	return rootSchema, nil
+}
+
+// newRootSchema builds the empty root schema shared by GenerateFromMap and
+// GenerateFromYAMLIncremental, before any properties are added.
+func (g *Generator) newRootSchema() *Schema {
+	return &Schema{
+		Schema:      g.Options.SchemaVersion,
+		Title:       g.Options.Title,
+		Description: g.Options.Description,
+		Type:        TypeObject,
+		Properties:  make(map[string]*Schema),
 	}
+}
 
-	// Set required properties if any
-	if len(required) > 0 {
-		rootSchema.Required = required
+// addProperty records propSchema as key's schema on rootSchema, marking key
+// required if RequireByDefault is set and value is non-nil.
+func (g *Generator) addProperty(rootSchema *Schema, key string, value any, propSchema *Schema) {
+	rootSchema.Properties[key] = propSchema
+	if g.Options.RequireByDefault && value != nil {
+		rootSchema.Required = append(rootSchema.Required, key)
 	}
+}
 
-	return rootSchema, nil
+// finalizeRootSchema applies the post-processing steps shared by
+// GenerateFromMap and GenerateFromYAMLIncremental, once every property has
+// been added to rootSchema.
+func (g *Generator) finalizeRootSchema(rootSchema *Schema) {
+	// Hoist repeated object subschemas into $defs/definitions and replace
+	// them with $ref, so callers of GenerateFromMap and GenerateFromYAML
+	// both benefit.
+	g.deduplicateSchemas(rootSchema)
+
+	if g.Options.DisallowUnevaluatedProperties {
+		applyUnevaluatedProperties(rootSchema, g.Options.SchemaVersion)
+	}
+
+	if g.Options.EmitCELValidations {
+		NewCELRuleBuilder(g.Options.MaxRuleCost).Apply(rootSchema)
+	}
+
+	if g.Options.SpecializeForHelm {
+		g.SpecializeSchemaForHelm(rootSchema)
+	}
 }
 
 // isLikelyYAMLOrJSON checks if a string appears to be a YAML or JSON string
@@ -0,0 +1,28 @@
+package jsonschema
+
+// assignTupleItems records items as schema's positional item schemas,
+// choosing the rendering keyword appropriate for the generator's configured
+// SchemaVersion (see Schema.TuplePrefixItems / Schema.TupleLegacyItems).
+func (g *Generator) assignTupleItems(schema *Schema, items []*Schema) {
+	if g.Options.SchemaVersion == Draft2020 {
+		schema.TuplePrefixItems = items
+	} else {
+		schema.TupleLegacyItems = items
+	}
+}
+
+// tupleItemsAreHomogeneous reports whether every positional item schema has
+// the same shape, in which case a tuple adds nothing over a plain items
+// schema and generation should fall back to the homogeneous form.
+func tupleItemsAreHomogeneous(items []*Schema) bool {
+	if len(items) == 0 {
+		return true
+	}
+	first := canonicalHash(items[0])
+	for _, item := range items[1:] {
+		if canonicalHash(item) != first {
+			return false
+		}
+	}
+	return true
+}
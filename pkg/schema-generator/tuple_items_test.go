@@ -0,0 +1,246 @@
+package jsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestInferTuples_SingleSample(t *testing.T) {
+	ctx := context.Background()
+
+	options := DefaultOptions()
+	options.InferTuples = true
+	options.ExtractDescriptions = false
+	generator := NewGenerator(options)
+
+	yamlData := []byte(`
+pair:
+  - myservice
+  - 8080
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	pair := schema.Properties["pair"]
+	if pair.Items != nil {
+		t.Errorf("expected Items to be unset for a tuple, got %+v", pair.Items)
+	}
+	if len(pair.TupleLegacyItems) != 2 {
+		t.Fatalf("expected 2 legacy tuple items on draft-07, got %d", len(pair.TupleLegacyItems))
+	}
+	if pair.TupleLegacyItems[0].Type != TypeString {
+		t.Errorf("expected position 0 to be string, got %v", pair.TupleLegacyItems[0].Type)
+	}
+	if pair.TupleLegacyItems[1].Type != TypeInteger {
+		t.Errorf("expected position 1 to be integer, got %v", pair.TupleLegacyItems[1].Type)
+	}
+
+	data, err := json.Marshal(pair)
+	if err != nil {
+		t.Fatalf("failed to marshal tuple schema: %v", err)
+	}
+	var rendered map[string]any
+	if err := json.Unmarshal(data, &rendered); err != nil {
+		t.Fatalf("failed to unmarshal rendered schema: %v", err)
+	}
+	if rendered["additionalItems"] != false {
+		t.Errorf("expected additionalItems: false, got %v", rendered["additionalItems"])
+	}
+	items, ok := rendered["items"].([]any)
+	if !ok || len(items) != 2 {
+		t.Errorf("expected items to render as a 2-element array, got %v", rendered["items"])
+	}
+}
+
+func TestInferTuples_Draft2020UsesPrefixItems(t *testing.T) {
+	ctx := context.Background()
+
+	options := DefaultOptions()
+	options.SchemaVersion = Draft2020
+	options.InferTuples = true
+	options.ExtractDescriptions = false
+	generator := NewGenerator(options)
+
+	yamlData := []byte(`
+pair:
+  - myservice
+  - 8080
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	pair := schema.Properties["pair"]
+	if len(pair.TuplePrefixItems) != 2 {
+		t.Fatalf("expected 2 prefixItems on 2020-12, got %d", len(pair.TuplePrefixItems))
+	}
+
+	data, err := json.Marshal(pair)
+	if err != nil {
+		t.Fatalf("failed to marshal tuple schema: %v", err)
+	}
+	var rendered map[string]any
+	if err := json.Unmarshal(data, &rendered); err != nil {
+		t.Fatalf("failed to unmarshal rendered schema: %v", err)
+	}
+	if rendered["items"] != false {
+		t.Errorf("expected items: false on 2020-12, got %v", rendered["items"])
+	}
+	if _, ok := rendered["prefixItems"].([]any); !ok {
+		t.Errorf("expected prefixItems to render as an array, got %v", rendered["prefixItems"])
+	}
+	if _, ok := rendered["additionalItems"]; ok {
+		t.Errorf("did not expect additionalItems on 2020-12, got %v", rendered["additionalItems"])
+	}
+}
+
+func TestInferTuples_NestedTuple(t *testing.T) {
+	ctx := context.Background()
+
+	options := DefaultOptions()
+	options.InferTuples = true
+	options.ExtractDescriptions = false
+	generator := NewGenerator(options)
+
+	yamlData := []byte(`
+grid:
+  - "label"
+  - - 1
+    - "x"
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	grid := schema.Properties["grid"]
+	if len(grid.TupleLegacyItems) != 2 {
+		t.Fatalf("expected 2 outer tuple items, got %d", len(grid.TupleLegacyItems))
+	}
+
+	inner := grid.TupleLegacyItems[1]
+	if len(inner.TupleLegacyItems) != 2 {
+		t.Fatalf("expected the nested array to also be inferred as a tuple, got %d items", len(inner.TupleLegacyItems))
+	}
+	if inner.TupleLegacyItems[0].Type != TypeInteger {
+		t.Errorf("expected nested position 0 to be integer, got %v", inner.TupleLegacyItems[0].Type)
+	}
+	if inner.TupleLegacyItems[1].Type != TypeString {
+		t.Errorf("expected nested position 1 to be string, got %v", inner.TupleLegacyItems[1].Type)
+	}
+}
+
+func TestInferTuples_DisabledByDefault(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGeneratorWithDefaults()
+	generator.Options.ExtractDescriptions = false
+
+	yamlData := []byte(`
+pair:
+  - myservice
+  - 8080
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	pair := schema.Properties["pair"]
+	if len(pair.TupleLegacyItems) != 0 || len(pair.TuplePrefixItems) != 0 {
+		t.Errorf("expected no tuple items when InferTuples is disabled, got legacy=%d prefix=%d", len(pair.TupleLegacyItems), len(pair.TuplePrefixItems))
+	}
+}
+
+func TestInferTuples_MultiSampleConsistentLength(t *testing.T) {
+	ctx := context.Background()
+
+	options := DefaultOptions()
+	options.InferTuples = true
+	options.ExtractDescriptions = false
+	generator := NewGenerator(options)
+
+	sources := [][]byte{
+		[]byte("pair:\n  - svc-a\n  - 8080\n"),
+		[]byte("pair:\n  - svc-b\n  - 9090\n"),
+	}
+
+	schema, err := generator.GenerateFromMultipleYAML(ctx, sources)
+	if err != nil {
+		t.Fatalf("GenerateFromMultipleYAML failed: %v", err)
+	}
+
+	pair := schema.Properties["pair"]
+	if len(pair.TupleLegacyItems) != 2 {
+		t.Fatalf("expected 2 tuple positions, got %d", len(pair.TupleLegacyItems))
+	}
+	if pair.TupleLegacyItems[0].Type != TypeString {
+		t.Errorf("expected position 0 to be string, got %v", pair.TupleLegacyItems[0].Type)
+	}
+	if pair.TupleLegacyItems[1].Type != TypeInteger {
+		t.Errorf("expected position 1 to be integer, got %v", pair.TupleLegacyItems[1].Type)
+	}
+}
+
+func TestInferTuples_MultiSampleInconsistentLengthFallsBack(t *testing.T) {
+	ctx := context.Background()
+
+	options := DefaultOptions()
+	options.InferTuples = true
+	options.ExtractDescriptions = false
+	generator := NewGenerator(options)
+
+	sources := [][]byte{
+		[]byte("pair:\n  - svc-a\n  - 8080\n"),
+		[]byte("pair:\n  - svc-b\n  - 9090\n  - extra\n"),
+	}
+
+	schema, err := generator.GenerateFromMultipleYAML(ctx, sources)
+	if err != nil {
+		t.Fatalf("GenerateFromMultipleYAML failed: %v", err)
+	}
+
+	pair := schema.Properties["pair"]
+	if len(pair.TupleLegacyItems) != 0 || len(pair.TuplePrefixItems) != 0 {
+		t.Errorf("expected fallback to homogeneous items on inconsistent lengths, got legacy=%d prefix=%d", len(pair.TupleLegacyItems), len(pair.TuplePrefixItems))
+	}
+	if pair.Items == nil {
+		t.Errorf("expected a homogeneous Items schema as the fallback")
+	}
+}
+
+func TestInferTuples_HomogeneousPositionsFallBack(t *testing.T) {
+	ctx := context.Background()
+
+	options := DefaultOptions()
+	options.InferTuples = true
+	options.ExtractDescriptions = false
+	generator := NewGenerator(options)
+
+	yamlData := []byte(`
+names:
+  - alice
+  - bob
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	names := schema.Properties["names"]
+	if len(names.TupleLegacyItems) != 0 {
+		t.Errorf("expected a homogeneous string array not to be treated as a tuple, got %d items", len(names.TupleLegacyItems))
+	}
+	if names.Items == nil || names.Items.Type != TypeString {
+		t.Errorf("expected a plain string Items schema, got %+v", names.Items)
+	}
+}
@@ -31,7 +31,14 @@ func isURI(s string) bool {
 	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
 }
 
-// inferSchema determines the JSON Schema type for a given value
+// inferSchema determines the JSON Schema type for a given value.
+//
+// This remains the single-source path, independent of mergeSamples (see
+// InferSchemaFromSources/GenerateFromMultipleYAML): the two have diverged
+// enough in responsibilities - format detection, recognizer specialization,
+// ArrayMixedStrategy, pattern-rule special cases - that folding one into the
+// other isn't a safe mechanical change and is left for a follow-up once
+// mergeSamples grows the same feature set.
 func (g *Generator) inferSchema(ctx context.Context, value any, path string) (*Schema, error) {
 	logger := logging.WithComponent(ctx, "json-schema-generator")
 
@@ -43,7 +50,7 @@ func (g *Generator) inferSchema(ctx context.Context, value any, path string) (*S
 		}
 
 		// Check if this path should support multiple types
-		if hasMultipleTypes, types := shouldSupportMultipleTypes(path); hasMultipleTypes {
+		if hasMultipleTypes, types := g.shouldSupportMultipleTypes(path); hasMultipleTypes {
 			schema.Type = types
 		}
 
@@ -66,7 +73,7 @@ func (g *Generator) inferSchema(ctx context.Context, value any, path string) (*S
 		}
 
 		// Check if this path should support multiple types
-		if hasMultipleTypes, types := shouldSupportMultipleTypes(path); hasMultipleTypes {
+		if hasMultipleTypes, types := g.shouldSupportMultipleTypes(path); hasMultipleTypes {
 			schema.Type = types
 		}
 
@@ -77,7 +84,7 @@ func (g *Generator) inferSchema(ctx context.Context, value any, path string) (*S
 		}
 
 		// Check if this path should support multiple types
-		if hasMultipleTypes, types := shouldSupportMultipleTypes(path); hasMultipleTypes {
+		if hasMultipleTypes, types := g.shouldSupportMultipleTypes(path); hasMultipleTypes {
 			schema.Type = types
 		}
 
@@ -88,7 +95,7 @@ func (g *Generator) inferSchema(ctx context.Context, value any, path string) (*S
 		}
 
 		// Check if this path should support multiple types
-		if hasMultipleTypes, types := shouldSupportMultipleTypes(path); hasMultipleTypes {
+		if hasMultipleTypes, types := g.shouldSupportMultipleTypes(path); hasMultipleTypes {
 			schema.Type = types
 		}
 
@@ -96,7 +103,7 @@ func (g *Generator) inferSchema(ctx context.Context, value any, path string) (*S
 		strValue := value.(string)
 
 		// Check if this path should support multiple types
-		if hasMultipleTypes, types := shouldSupportMultipleTypes(path); hasMultipleTypes {
+		if hasMultipleTypes, types := g.shouldSupportMultipleTypes(path); hasMultipleTypes {
 			schema.Type = types
 		} else if isLikelyYAMLOrJSON(strValue) {
 			// For fields that could be both string and object/array
@@ -113,6 +120,8 @@ func (g *Generator) inferSchema(ctx context.Context, value any, path string) (*S
 				schema.Format = "email"
 			} else if isURI(strValue) {
 				schema.Format = "uri"
+			} else if format := g.detectFormat(strValue); format != "" {
+				schema.Format = format
 			}
 		}
 
@@ -135,7 +144,7 @@ func (g *Generator) inferSchema(ctx context.Context, value any, path string) (*S
 			// This is valid in JSON Schema and means "any type" for array items
 
 			// Check if this path should support multiple types
-			if hasMultipleTypes, types := shouldSupportMultipleTypes(path); hasMultipleTypes {
+			if hasMultipleTypes, types := g.shouldSupportMultipleTypes(path); hasMultipleTypes {
 				schema.Type = types
 			}
 		} else {
@@ -145,33 +154,34 @@ func (g *Generator) inferSchema(ctx context.Context, value any, path string) (*S
 				sliceInterface[i] = sliceValue.Index(i).Interface()
 			}
 
-			if hasMixedTypes(sliceInterface) {
-				// Use our new method for mixed type arrays
-				mixedSchema, err := g.InferArrayItemsWithMultipleTypes(ctx, sliceInterface, path)
-				if err != nil {
+			if g.Options.InferTuples && sliceValue.Len() >= 2 && hasMixedTypes(sliceInterface) {
+				tupleItems := make([]*Schema, len(sliceInterface))
+				for i, elem := range sliceInterface {
+					itemSchema, err := g.inferSchema(ctx, elem, fmt.Sprintf("%s[%d]", path, i))
+					if err != nil {
+						logger.ErrorContext(ctx, "failed to infer schema for tuple item", "path", path, "index", i, "error", err)
+						return nil, fmt.Errorf("failed to infer schema for tuple item %d: %w", i, err)
+					}
+					tupleItems[i] = itemSchema
+				}
+				g.assignTupleItems(schema, tupleItems)
+			} else if hasMixedTypes(sliceInterface) {
+				if err := g.inferMixedTypeArrayItems(ctx, schema, sliceInterface, path); err != nil {
 					logger.ErrorContext(ctx, "failed to infer schema for mixed type array", "path", path, "error", err)
 					return nil, fmt.Errorf("failed to infer schema for mixed type array: %w", err)
 				}
-				// We want to keep the array type but use the types from the mixed type handling
-				schema.Items = &Schema{
-					Type: mixedSchema.Type,
-				}
 			} else {
-				// Get the first item to infer type for homogeneous arrays
-				firstItem := sliceValue.Index(0).Interface()
-				itemPath := fmt.Sprintf("%s[0]", path)
-
-				itemSchema, err := g.inferSchema(ctx, firstItem, itemPath)
+				itemSchema, err := g.inferArrayItems(ctx, sliceInterface, path)
 				if err != nil {
-					logger.ErrorContext(ctx, "failed to infer schema for array item", "path", itemPath, "error", err)
-					return nil, fmt.Errorf("failed to infer schema for array item: %w", err)
+					logger.ErrorContext(ctx, "failed to infer schema for array items", "path", path, "error", err)
+					return nil, fmt.Errorf("failed to infer schema for array items: %w", err)
 				}
 
 				schema.Items = itemSchema
 			}
 
 			// Check if this path should support multiple types
-			if hasMultipleTypes, types := shouldSupportMultipleTypes(path); hasMultipleTypes {
+			if hasMultipleTypes, types := g.shouldSupportMultipleTypes(path); hasMultipleTypes {
 				schema.Type = types
 			}
 		}
@@ -226,7 +236,7 @@ func (g *Generator) inferSchema(ctx context.Context, value any, path string) (*S
 		}
 
 		// Check if this path should support multiple types
-		if hasMultipleTypes, types := shouldSupportMultipleTypes(path); hasMultipleTypes {
+		if hasMultipleTypes, types := g.shouldSupportMultipleTypes(path); hasMultipleTypes {
 			schema.Type = types
 		}
 
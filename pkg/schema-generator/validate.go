@@ -0,0 +1,168 @@
+package jsonschema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dc-tec/helm-schema-gen/pkg/logging"
+	santhoshschema "github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v2"
+)
+
+// ValidationError is a single structural JSON Schema failure, translated
+// from the underlying validator's error tree into a stable, machine-readable
+// shape so callers don't need to depend on a third-party error type.
+type ValidationError struct {
+	// Path is the JSON Pointer location of the offending value within the
+	// values document (e.g. "/resources/limits/cpu").
+	Path string
+	// Keyword is the JSON Schema keyword that failed (e.g. "type", "required").
+	Keyword string
+	// Message describes why the value failed that keyword.
+	Message string
+}
+
+// draftForVersion maps a SchemaVersion to the validator's built-in draft,
+// defaulting to Draft-07 to match DefaultOptions.
+func draftForVersion(version SchemaVersion) *santhoshschema.Draft {
+	switch version {
+	case Draft4:
+		return santhoshschema.Draft4
+	case Draft6:
+		return santhoshschema.Draft6
+	case Draft2019:
+		return santhoshschema.Draft2019
+	case Draft2020:
+		return santhoshschema.Draft2020
+	default:
+		return santhoshschema.Draft7
+	}
+}
+
+// Validate checks values (a Helm values.yaml document) against schema using
+// a JSON Schema validator configured for schema's declared $schema draft,
+// returning every structural validation failure it finds. A nil slice with a
+// nil error means values validated successfully.
+func (g *Generator) Validate(ctx context.Context, schema *Schema, values []byte) ([]ValidationError, error) {
+	logger := logging.WithComponent(ctx, "json-schema-generator")
+	logger.InfoContext(ctx, "validating values against schema")
+
+	var rawValues any
+	if err := yaml.Unmarshal(values, &rawValues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal values: %w", err)
+	}
+	valuesDoc, err := convertYAMLToStringMap(rawValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert values: %w", err)
+	}
+
+	errs, err := compileAndValidate(schema, valuesDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "validation completed", "errorCount", len(errs))
+	return errs, nil
+}
+
+// Validate checks an already-parsed values document (e.g. the result of
+// yaml.Unmarshal) against schema for Draft-07/2019-09/2020-12 structural
+// conformance - type, enum, pattern, minimum/maximum, minItems/maxItems,
+// required, oneOf/anyOf/allOf, and $ref/definitions resolution - returning
+// every failure as an Error-level ValidationIssue. Reusing ValidationIssue
+// (see ValidateHelmBestPractices) means the same FormatValidationIssues
+// formatter renders both a schema's quality issues and a values document's
+// conformance failures. A schema or values document that can't be compiled
+// at all is also reported as a single issue rather than a panic or a
+// silently empty result.
+func Validate(schema *Schema, values any) []ValidationIssue {
+	valuesDoc, err := convertYAMLToStringMap(values)
+	if err != nil {
+		return []ValidationIssue{{Message: fmt.Sprintf("failed to convert values: %v", err), Level: Error}}
+	}
+
+	errs, err := compileAndValidate(schema, valuesDoc)
+	if err != nil {
+		return []ValidationIssue{{Message: err.Error(), Level: Error}}
+	}
+
+	issues := make([]ValidationIssue, 0, len(errs))
+	for _, e := range errs {
+		issues = append(issues, ValidationIssue{
+			Path:    e.Path,
+			Message: fmt.Sprintf("%s (keyword: %s)", e.Message, e.Keyword),
+			Level:   Error,
+		})
+	}
+	return issues
+}
+
+// compileAndValidate compiles schema for its declared $schema draft and
+// validates valuesDoc against it, flattening any failures into
+// ValidationError entries.
+func compileAndValidate(schema *Schema, valuesDoc any) ([]ValidationError, error) {
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema: %w", err)
+	}
+
+	compiler := santhoshschema.NewCompiler()
+	compiler.Draft = draftForVersion(schema.Schema)
+	compiler.AssertFormat = true
+	for name, checker := range registeredFormatCheckers() {
+		compiler.Formats[name] = checker
+	}
+	if err := compiler.AddResource("schema.json", bytes.NewReader(schemaJSON)); err != nil {
+		return nil, fmt.Errorf("failed to load schema: %w", err)
+	}
+	compiled, err := compiler.Compile("schema.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema: %w", err)
+	}
+
+	if err := compiled.Validate(valuesDoc); err != nil {
+		validationErr, ok := err.(*santhoshschema.ValidationError)
+		if !ok {
+			return nil, fmt.Errorf("failed to validate values: %w", err)
+		}
+		return flattenValidationErrors(validationErr), nil
+	}
+
+	return nil, nil
+}
+
+// flattenValidationErrors walks a santhosh-tekuri/jsonschema error tree -
+// whose root node typically just aggregates its Causes - down to the leaf
+// errors, which carry the actual keyword and message.
+func flattenValidationErrors(ve *santhoshschema.ValidationError) []ValidationError {
+	var errs []ValidationError
+
+	var walk func(*santhoshschema.ValidationError)
+	walk = func(e *santhoshschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			errs = append(errs, ValidationError{
+				Path:    e.InstanceLocation,
+				Keyword: lastKeywordSegment(e.KeywordLocation),
+				Message: e.Message,
+			})
+			return
+		}
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(ve)
+
+	return errs
+}
+
+// lastKeywordSegment extracts the keyword name (e.g. "required", "type")
+// from a validator KeywordLocation JSON Pointer such as
+// "/properties/resources/required".
+func lastKeywordSegment(keywordLocation string) string {
+	parts := strings.Split(keywordLocation, "/")
+	return parts[len(parts)-1]
+}
@@ -0,0 +1,164 @@
+package jsonschema
+
+import (
+	"context"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestValidate_PassingValues(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion: Draft07,
+	})
+
+	yamlData := []byte(`
+replicaCount: 1
+image:
+  repository: nginx
+  tag: "1.25"
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	values := []byte(`
+replicaCount: 3
+image:
+  repository: myapp
+  tag: "2.0"
+`)
+
+	errs, err := generator.Validate(ctx, schema, values)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("expected no validation errors, got %+v", errs)
+	}
+}
+
+func TestValidate_TypeMismatch(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion: Draft07,
+	})
+
+	yamlData := []byte(`
+replicaCount: 1
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	values := []byte(`
+replicaCount: "not-a-number"
+`)
+
+	errs, err := generator.Validate(ctx, schema, values)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 validation error, got %d: %+v", len(errs), errs)
+	}
+	if errs[0].Path != "/replicaCount" {
+		t.Errorf("expected path /replicaCount, got %q", errs[0].Path)
+	}
+	if errs[0].Keyword != "type" {
+		t.Errorf("expected keyword type, got %q", errs[0].Keyword)
+	}
+}
+
+func TestValidate_MissingRequiredField(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{
+		SchemaVersion:    Draft07,
+		RequireByDefault: true,
+	})
+
+	yamlData := []byte(`
+image:
+  repository: nginx
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	values := []byte(`
+image: {}
+`)
+
+	errs, err := generator.Validate(ctx, schema, values)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 validation error, got %d: %+v", len(errs), errs)
+	}
+	if errs[0].Keyword != "required" {
+		t.Errorf("expected keyword required, got %q", errs[0].Keyword)
+	}
+}
+
+func TestValidate_PackageLevelAPIReturnsIssues(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+	yamlData := []byte(`
+replicaCount: 1
+`)
+
+	schema, err := generator.GenerateFromYAML(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	var values any
+	if err := yaml.Unmarshal([]byte("replicaCount: \"not-a-number\"\n"), &values); err != nil {
+		t.Fatalf("failed to unmarshal values: %v", err)
+	}
+
+	issues := Validate(schema, values)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 validation issue, got %d: %+v", len(issues), issues)
+	}
+	if issues[0].Level != Error {
+		t.Errorf("expected Error-level issue, got %v", issues[0].Level)
+	}
+	if issues[0].Path != "/replicaCount" {
+		t.Errorf("expected path /replicaCount, got %q", issues[0].Path)
+	}
+}
+
+func TestValidate_PackageLevelAPIPassingValues(t *testing.T) {
+	ctx := context.Background()
+
+	generator := NewGenerator(GeneratorOptions{SchemaVersion: Draft07})
+
+	schema, err := generator.GenerateFromYAML(ctx, []byte("replicaCount: 1\n"))
+	if err != nil {
+		t.Fatalf("GenerateFromYAML failed: %v", err)
+	}
+
+	var values any
+	if err := yaml.Unmarshal([]byte("replicaCount: 3\n"), &values); err != nil {
+		t.Fatalf("failed to unmarshal values: %v", err)
+	}
+
+	issues := Validate(schema, values)
+	if len(issues) != 0 {
+		t.Errorf("expected no validation issues, got %+v", issues)
+	}
+}